@@ -0,0 +1,78 @@
+package apperror
+
+import (
+	"errors"
+	"net/http"
+)
+
+// AppError adalah error aplikasi dengan kode stabil yang bisa dipakai client untuk branching,
+// status HTTP, pesan untuk user, dan penyebab asli (cause) untuk log.
+type AppError struct {
+	Code    string
+	Status  int
+	Message string
+	Cause   error
+}
+
+const CodeInternal = "INTERNAL_ERROR"
+
+var ErrInternal = New(CodeInternal, http.StatusInternalServerError, "internal server error")
+
+func New(code string, status int, message string) *AppError {
+	return &AppError{
+		Code:    code,
+		Status:  status,
+		Message: message,
+	}
+}
+
+func (e *AppError) Error() string {
+	if e.Cause != nil {
+		return e.Message + ": " + e.Cause.Error()
+	}
+
+	return e.Message
+}
+
+func (e *AppError) Unwrap() error {
+	return e.Cause
+}
+
+// Is membandingkan berdasarkan Code sehingga salinan hasil Wrap tetap cocok dengan sentinel-nya.
+func (e *AppError) Is(target error) bool {
+	var t *AppError
+	if !errors.As(target, &t) {
+		return false
+	}
+
+	return t.Code == e.Code
+}
+
+// Wrap mengembalikan salinan error dengan cause, sentinel aslinya tidak diubah.
+func (e *AppError) Wrap(cause error) *AppError {
+	wrapped := *e
+	wrapped.Cause = cause
+
+	return &wrapped
+}
+
+// From mencari AppError di rantai err; error lain dianggap internal error.
+func From(err error) *AppError {
+	var appErr *AppError
+	if errors.As(err, &appErr) {
+		return appErr
+	}
+
+	return ErrInternal.Wrap(err)
+}
+
+// PublicMessage mengembalikan teks error yang aman ditampilkan: detail lengkap untuk error 4xx,
+// hanya pesan umum untuk 5xx agar detail internal tidak bocor ke client.
+func PublicMessage(err error) string {
+	appErr := From(err)
+	if appErr.Status < http.StatusInternalServerError {
+		return err.Error()
+	}
+
+	return appErr.Message
+}
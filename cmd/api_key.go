@@ -0,0 +1,85 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/Amierza/worker-service/config"
+	"github.com/Amierza/worker-service/dto"
+	"github.com/Amierza/worker-service/repository"
+	"github.com/Amierza/worker-service/service"
+)
+
+func runMintAPIKey(ctx context.Context, args []string) error {
+	fs := newFlagSet("mint-api-key")
+	name := fs.String("name", "", "API key name")
+	scopes := fs.String("scopes", "", "comma separated API key scopes, e.g. tasks:read,tasks:retry")
+	expiresIn := fs.Duration("expires-in", 0, "API key lifetime, 0 means the key never expires")
+	if ok, err := parseFlags(fs, args); !ok {
+		return err
+	}
+
+	a, err := newApp(config.RequireDatabase)
+	if err != nil {
+		return err
+	}
+	defer a.Close()
+
+	apiKeyService := service.NewAPIKeyService(repository.NewAPIKeyRepository(a.db), a.logger)
+	res, err := apiKeyService.MintAPIKey(ctx, dto.MintAPIKeyRequest{
+		Name:      *name,
+		Scopes:    splitScopes(*scopes),
+		ExpiresIn: *expiresIn,
+	})
+	if err != nil {
+		return fmt.Errorf("error mint api key: %w", err)
+	}
+
+	log.Printf("api key minted: id=%s name=%s scopes=%s", res.ID, res.Name, strings.Join(res.Scopes, ","))
+	if res.ExpiresAt != nil {
+		log.Printf("api key expires at %s", res.ExpiresAt.Format(time.RFC3339))
+	}
+	// key hanya ditampilkan sekali, dicetak ke stdout agar mudah dipipe
+	fmt.Println(res.Key)
+	return nil
+}
+
+func runRevokeAPIKey(ctx context.Context, args []string) error {
+	fs := newFlagSet("revoke-api-key")
+	id := fs.String("id", "", "id of the API key to revoke")
+	if ok, err := parseFlags(fs, args); !ok {
+		return err
+	}
+	if *id == "" {
+		return errors.New("revoke-api-key: --id is required")
+	}
+
+	a, err := newApp(config.RequireDatabase)
+	if err != nil {
+		return err
+	}
+	defer a.Close()
+
+	apiKeyService := service.NewAPIKeyService(repository.NewAPIKeyRepository(a.db), a.logger)
+	if err := apiKeyService.RevokeAPIKey(ctx, *id); err != nil {
+		return fmt.Errorf("error revoke api key: %w", err)
+	}
+
+	log.Printf("api key %s revoked", *id)
+	return nil
+}
+
+func splitScopes(value string) []string {
+	var scopes []string
+	for _, scope := range strings.Split(value, ",") {
+		if scope = strings.TrimSpace(scope); scope != "" {
+			scopes = append(scopes, scope)
+		}
+	}
+
+	return scopes
+}
@@ -0,0 +1,111 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/Amierza/worker-service/config"
+	"github.com/Amierza/worker-service/config/database"
+	"github.com/Amierza/worker-service/config/rabbitmq"
+	grpcclient "github.com/Amierza/worker-service/grpc_client"
+	"github.com/Amierza/worker-service/jwt"
+	"github.com/Amierza/worker-service/logger"
+	"github.com/Amierza/worker-service/repository"
+	"github.com/Amierza/worker-service/tracing"
+	amqp "github.com/rabbitmq/amqp091-go"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+// app menampung dependency yang dibuka sebuah perintah. Field yang tidak termasuk dalam
+// requirement perintah tersebut tetap nil.
+type app struct {
+	cfg        *config.Config
+	logger     *zap.Logger
+	logLevel   zap.AtomicLevel
+	db         *gorm.DB
+	rabbitConn *amqp.Connection
+	grpcClient *grpcclient.SummaryClient
+	jwtService *jwt.JWT
+
+	closers []func()
+}
+
+// newApp memuat & memvalidasi konfigurasi yang dibutuhkan lalu hanya membuka dependency
+// sesuai required. Pemanggil wajib memanggil Close.
+func newApp(required config.Requirement) (*app, error) {
+	cfg, err := config.New("", required)
+	if err != nil {
+		return nil, err
+	}
+
+	// waktu lokal proses mengikuti zona database agar timestamp log & query konsisten
+	location, err := time.LoadLocation(cfg.Database.TimeZone)
+	if err != nil {
+		return nil, fmt.Errorf("invalid database timezone %q: %w", cfg.Database.TimeZone, err)
+	}
+	time.Local = location
+
+	appLogger, err := logger.New(cfg.Log)
+	if err != nil {
+		return nil, fmt.Errorf("failed to init logger: %w", err)
+	}
+
+	a := &app{cfg: cfg, logger: appLogger.Logger, logLevel: appLogger.Level}
+	a.onClose(func() { _ = appLogger.Close() })
+
+	shutdownTracing, err := tracing.Setup(context.Background(), cfg.Tracing, cfg.App.Env)
+	if err != nil {
+		a.Close()
+		return nil, fmt.Errorf("failed to setup tracing: %w", err)
+	}
+	a.onClose(func() {
+		ctx, cancel := context.WithTimeout(context.Background(), shutdownTimeout)
+		defer cancel()
+		if err := shutdownTracing(ctx); err != nil {
+			a.logger.Error("failed to flush traces", zap.Error(err))
+		}
+	})
+
+	if required&config.RequireDatabase != 0 {
+		a.db = database.SetUpPostgreSQLConnection(cfg.Database, a.logger)
+		a.onClose(func() { database.ClosePostgreSQLConnection(a.db) })
+	}
+
+	if required&config.RequireRabbitMQ != 0 {
+		a.rabbitConn = rabbitmq.SetUpRabbitMQConnection(cfg.RabbitMQ)
+		a.onClose(func() { rabbitmq.CloseRabbitMQConnection(a.rabbitConn) })
+	}
+
+	if required&config.RequireAIService != 0 {
+		a.grpcClient, err = grpcclient.NewSummaryClient(cfg.AIService.GRPCAddr)
+		if err != nil {
+			a.Close()
+			return nil, fmt.Errorf("failed to connect to AI gRPC service: %w", err)
+		}
+		a.onClose(a.grpcClient.Close)
+	}
+
+	// refresh token & revocation disimpan di database, jadi RequireJWT selalu disertai RequireDatabase
+	if required&config.RequireJWT != 0 {
+		a.jwtService, err = jwt.NewJWT(cfg.JWT, repository.NewTokenRepository(a.db))
+		if err != nil {
+			a.Close()
+			return nil, fmt.Errorf("failed to setup jwt: %w", err)
+		}
+	}
+
+	return a, nil
+}
+
+func (a *app) onClose(fn func()) {
+	a.closers = append(a.closers, fn)
+}
+
+// Close menutup dependency dengan urutan terbalik dari saat dibuka.
+func (a *app) Close() {
+	for i := len(a.closers) - 1; i >= 0; i-- {
+		a.closers[i]()
+	}
+}
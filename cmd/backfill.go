@@ -0,0 +1,100 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/Amierza/worker-service/config"
+	"github.com/Amierza/worker-service/constants"
+	"github.com/Amierza/worker-service/dto"
+	"github.com/Amierza/worker-service/helper"
+	"github.com/Amierza/worker-service/repository"
+	"github.com/Amierza/worker-service/service"
+	"github.com/google/uuid"
+)
+
+// runBackfill menjalankan backfill ringkasan sebagai job sekali jalan; gRPC AI service hanya
+// dibuka untuk mode process dan dry-run hanya membuka database.
+func runBackfill(ctx context.Context, args []string) error {
+	fs := newFlagSet("backfill")
+	name := fs.String("checkpoint", "default", "backfill checkpoint name, used to resume")
+	mode := fs.String("mode", constants.ENUM_BACKFILL_MODE_ENQUEUE, "backfill mode: enqueue or process")
+	dryRun := fs.Bool("dry-run", false, "list backfill candidates without enqueueing or processing")
+	reset := fs.Bool("reset", false, "ignore the saved checkpoint and start from the beginning")
+	rate := fs.Float64("rate", 1, "backfill throughput in sessions per second")
+	limit := fs.Int("limit", 0, "maximum sessions to backfill, 0 means no limit")
+	facultyID := fs.String("faculty-id", "", "only backfill sessions of this faculty")
+	from := fs.String("from", "", "only backfill sessions created at or after this date (YYYY-MM-DD)")
+	to := fs.String("to", "", "only backfill sessions created before this date (YYYY-MM-DD)")
+	stuckAfter := fs.Duration("stuck-after", time.Hour, "treat processing_summary sessions older than this as stuck")
+	if ok, err := parseFlags(fs, args); !ok {
+		return err
+	}
+
+	req := dto.BackfillRequest{
+		Name:   *name,
+		Mode:   *mode,
+		DryRun: *dryRun,
+		Reset:  *reset,
+		Rate:   *rate,
+		Limit:  *limit,
+		Filter: dto.BackfillFilter{
+			StuckAfter: *stuckAfter,
+		},
+	}
+
+	if *facultyID != "" {
+		id, err := uuid.Parse(*facultyID)
+		if err != nil {
+			return fmt.Errorf("invalid --faculty-id: %w", err)
+		}
+		req.Filter.FacultyID = &id
+	}
+
+	if *from != "" {
+		t, err := time.ParseInLocation(time.DateOnly, *from, helper.DefaultLocation())
+		if err != nil {
+			return fmt.Errorf("invalid --from: %w", err)
+		}
+		req.Filter.From = &t
+	}
+
+	if *to != "" {
+		t, err := time.ParseInLocation(time.DateOnly, *to, helper.DefaultLocation())
+		if err != nil {
+			return fmt.Errorf("invalid --to: %w", err)
+		}
+		req.Filter.To = &t
+	}
+
+	required := config.RequireDatabase
+	if !req.DryRun {
+		required |= config.RequireRabbitMQ
+		if req.Mode == constants.ENUM_BACKFILL_MODE_PROCESS {
+			required |= config.RequireAIService
+		}
+	}
+
+	a, err := newApp(required)
+	if err != nil {
+		return err
+	}
+	defer a.Close()
+
+	var (
+		txManager       = repository.NewTransactionManager(a.db)
+		consumerRepo    = repository.NewConsumerRepository(a.db)
+		consumerService = service.NewConsumerService(consumerRepo, repository.NewOutboxRepository(a.db), repository.NewTaskRepository(a.db), txManager, a.logger, a.rabbitConn, nil, a.grpcClient, a.cfg.Worker.TaskTimeout)
+		backfillService = service.NewBackfillService(repository.NewBackfillRepository(a.db), consumerRepo, consumerService, a.logger, a.rabbitConn)
+	)
+
+	result, err := backfillService.RunBackfill(ctx, req)
+	if err != nil {
+		return fmt.Errorf("backfill failed: %w", err)
+	}
+
+	log.Printf("backfill done: scanned=%d succeeded=%d failed=%d", result.Scanned, result.Succeeded, result.Failed)
+	return nil
+}
@@ -0,0 +1,164 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/Amierza/worker-service/config"
+	"github.com/Amierza/worker-service/migrations"
+)
+
+type command struct {
+	name    string
+	summary string
+	run     func(ctx context.Context, args []string) error
+}
+
+// commands adalah daftar subcommand. Setiap perintah hanya membuka dependency yang dibutuhkan,
+// sehingga worker & api bisa di-deploy dan di-scale terpisah.
+var commands = []command{
+	{name: modeWorker, summary: "run the RabbitMQ consumer and outbox relay (HTTP only serves health, metrics and consumer control)", run: serveCommand(modeWorker)},
+	{name: modeAPI, summary: "run the HTTP API only", run: serveCommand(modeAPI)},
+	{name: modeAll, summary: "run worker and API in one process (default)", run: serveCommand(modeAll)},
+	{name: "migrate", summary: "run pending database migrations", run: runMigrate},
+	{name: "rollback", summary: "rollback applied database migrations", run: runRollback},
+	{name: "seed", summary: "seed master data from fixture files", run: runSeed},
+	{name: "enqueue", summary: "publish a TaskSummary from a JSON file to the summary queue", run: runEnqueue},
+	{name: "replay-dlq", summary: "move dead-lettered summary tasks back to the summary queue", run: runReplayDLQ},
+	{name: "backfill", summary: "summarize finished sessions that have no summary yet", run: runBackfill},
+	{name: "mint-api-key", summary: "mint a new API key and print it once", run: runMintAPIKey},
+	{name: "revoke-api-key", summary: "revoke an API key", run: runRevokeAPIKey},
+	{name: "config", summary: "print the effective configuration with secrets redacted (config print)", run: runConfig},
+}
+
+// Execute menjalankan subcommand pada args (tanpa nama program). Tanpa argumen, mode all
+// dijalankan agar perilaku `go run main.go` tetap sama.
+func Execute(ctx context.Context, args []string) error {
+	if len(args) == 0 {
+		return Serve(ctx, modeAll)
+	}
+
+	name := args[0]
+	if name == "help" || name == "-h" || name == "--help" {
+		printUsage()
+		return nil
+	}
+
+	for _, c := range commands {
+		if c.name == name {
+			return c.run(ctx, args[1:])
+		}
+	}
+
+	printUsage()
+	return fmt.Errorf("unknown command %q", name)
+}
+
+func printUsage() {
+	w := tabwriter.NewWriter(os.Stderr, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "Usage: worker-service <command> [flags]")
+	fmt.Fprintln(w)
+	fmt.Fprintln(w, "Commands:")
+	for _, c := range commands {
+		fmt.Fprintf(w, "  %s\t%s\n", c.name, c.summary)
+	}
+	fmt.Fprintln(w)
+	fmt.Fprintln(w, "Run 'worker-service <command> -h' for the flags of a command.")
+	w.Flush()
+}
+
+// newFlagSet membuat flag set per perintah; parse error dikembalikan, bukan os.Exit.
+func newFlagSet(name string) *flag.FlagSet {
+	return flag.NewFlagSet(name, flag.ContinueOnError)
+}
+
+// parseFlags menganggap -h sebagai sukses agar bantuan flag tidak dilaporkan sebagai error.
+func parseFlags(fs *flag.FlagSet, args []string) (bool, error) {
+	if err := fs.Parse(args); err != nil {
+		if errors.Is(err, flag.ErrHelp) {
+			return false, nil
+		}
+		return false, err
+	}
+
+	if fs.NArg() > 0 {
+		return false, fmt.Errorf("%s: unexpected arguments: %s", fs.Name(), strings.Join(fs.Args(), " "))
+	}
+
+	return true, nil
+}
+
+func serveCommand(mode string) func(ctx context.Context, args []string) error {
+	return func(ctx context.Context, args []string) error {
+		if ok, err := parseFlags(newFlagSet(mode), args); !ok {
+			return err
+		}
+
+		return Serve(ctx, mode)
+	}
+}
+
+func runMigrate(ctx context.Context, args []string) error {
+	if ok, err := parseFlags(newFlagSet("migrate"), args); !ok {
+		return err
+	}
+
+	a, err := newApp(config.RequireDatabase)
+	if err != nil {
+		return err
+	}
+	defer a.Close()
+
+	if err := migrations.Migrate(ctx, a.db); err != nil {
+		return fmt.Errorf("error migration: %w", err)
+	}
+
+	log.Println("migration completed successfully")
+	return nil
+}
+
+func runRollback(ctx context.Context, args []string) error {
+	fs := newFlagSet("rollback")
+	steps := fs.Int("steps", 1, "number of migrations to rollback")
+	if ok, err := parseFlags(fs, args); !ok {
+		return err
+	}
+
+	a, err := newApp(config.RequireDatabase)
+	if err != nil {
+		return err
+	}
+	defer a.Close()
+
+	if err := migrations.Rollback(ctx, a.db, *steps); err != nil {
+		return fmt.Errorf("error rollback: %w", err)
+	}
+
+	log.Println("rollback completed successfully")
+	return nil
+}
+
+func runSeed(ctx context.Context, args []string) error {
+	if ok, err := parseFlags(newFlagSet("seed"), args); !ok {
+		return err
+	}
+
+	a, err := newApp(config.RequireDatabase)
+	if err != nil {
+		return err
+	}
+	defer a.Close()
+
+	if err := migrations.Seed(ctx, a.db); err != nil {
+		return fmt.Errorf("error seed: %w", err)
+	}
+
+	log.Println("seed completed successfully")
+	return nil
+}
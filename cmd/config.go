@@ -0,0 +1,31 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"os"
+
+	"github.com/Amierza/worker-service/config"
+)
+
+// runConfig menjalankan `config print`: mencetak konfigurasi efektif (secret disensor) ke stdout
+// lalu hasil validasinya, sehingga bisa dipakai untuk mengecek deployment tanpa start aplikasi.
+func runConfig(ctx context.Context, args []string) error {
+	if len(args) == 0 || args[0] != "print" {
+		return errors.New("usage: config print")
+	}
+	if ok, err := parseFlags(newFlagSet("config print"), args[1:]); !ok {
+		return err
+	}
+
+	cfg, err := config.Load("")
+	if err != nil {
+		return err
+	}
+
+	if err := cfg.Print(os.Stdout); err != nil {
+		return err
+	}
+
+	return cfg.Validate(config.RequireAll)
+}
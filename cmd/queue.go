@@ -0,0 +1,81 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"os"
+
+	"github.com/Amierza/worker-service/config"
+	"github.com/Amierza/worker-service/service"
+)
+
+// runEnqueue mengirim TaskSummary dari file JSON (atau stdin dengan --file -) ke queue summary.
+func runEnqueue(ctx context.Context, args []string) error {
+	fs := newFlagSet("enqueue")
+	file := fs.String("file", "", "path to a TaskSummary JSON file, - reads from stdin")
+	if ok, err := parseFlags(fs, args); !ok {
+		return err
+	}
+	if *file == "" {
+		return errors.New("enqueue: --file is required")
+	}
+
+	body, err := readInput(*file)
+	if err != nil {
+		return err
+	}
+
+	a, err := newApp(config.RequireRabbitMQ)
+	if err != nil {
+		return err
+	}
+	defer a.Close()
+
+	taskID, err := service.NewQueueService(a.logger, a.rabbitConn).EnqueueSummaryTask(ctx, body)
+	if err != nil {
+		return fmt.Errorf("error enqueue: %w", err)
+	}
+
+	log.Println("summary task enqueued")
+	// id task dicetak ke stdout agar bisa dipakai untuk melacak task di ledger
+	fmt.Println(taskID)
+	return nil
+}
+
+func runReplayDLQ(ctx context.Context, args []string) error {
+	fs := newFlagSet("replay-dlq")
+	limit := fs.Int("limit", 0, "maximum messages to replay, 0 means all messages currently in the DLQ")
+	if ok, err := parseFlags(fs, args); !ok {
+		return err
+	}
+
+	a, err := newApp(config.RequireRabbitMQ)
+	if err != nil {
+		return err
+	}
+	defer a.Close()
+
+	replayed, err := service.NewQueueService(a.logger, a.rabbitConn).ReplayDeadLetters(ctx, *limit)
+	log.Printf("replayed %d dead-lettered task(s)", replayed)
+	if err != nil {
+		return fmt.Errorf("error replay dlq: %w", err)
+	}
+
+	return nil
+}
+
+func readInput(path string) ([]byte, error) {
+	if path == "-" {
+		return io.ReadAll(os.Stdin)
+	}
+
+	body, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s: %w", path, err)
+	}
+
+	return body, nil
+}
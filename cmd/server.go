@@ -0,0 +1,222 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/Amierza/worker-service/config"
+	"github.com/Amierza/worker-service/dto"
+	"github.com/Amierza/worker-service/handler"
+	"github.com/Amierza/worker-service/middleware"
+	"github.com/Amierza/worker-service/repository"
+	"github.com/Amierza/worker-service/routes"
+	"github.com/Amierza/worker-service/service"
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+)
+
+const (
+	modeWorker = "worker"
+	modeAPI    = "api"
+	modeAll    = "all"
+
+	// shutdownTimeout memberi waktu request HTTP yang sedang berjalan untuk selesai
+	shutdownTimeout = 10 * time.Second
+)
+
+// requirementsFor: hanya worker yang memanggil AI service lewat gRPC; mode api tetap butuh
+// database, rabbitmq (retry & resummarize task) dan JWT untuk endpoint admin.
+func requirementsFor(mode string) config.Requirement {
+	if mode == modeAPI {
+		return config.RequireDatabase | config.RequireRabbitMQ | config.RequireJWT
+	}
+
+	return config.RequireAll
+}
+
+// Serve menjalankan mode worker (consumer + outbox relay), api (HTTP) atau all (keduanya)
+// sampai ctx dibatalkan. Mode worker tetap membuka HTTP untuk health, metrics & kontrol consumer.
+func Serve(ctx context.Context, mode string) error {
+	a, err := newApp(requirementsFor(mode))
+	if err != nil {
+		return err
+	}
+	defer a.Close()
+
+	runWorker := mode == modeWorker || mode == modeAll
+	runAPI := mode == modeAPI || mode == modeAll
+
+	var (
+		// Auth
+		authService = service.NewAuthService(a.jwtService, a.logger)
+		authHandler = handler.NewAuthHandler(authService)
+
+		// User
+		userRepo    = repository.NewUserRepository(a.db)
+		userService = service.NewUserService(userRepo)
+
+		// API key
+		apiKeyRepo    = repository.NewAPIKeyRepository(a.db)
+		apiKeyService = service.NewAPIKeyService(apiKeyRepo, a.logger)
+
+		// Authorization
+		authorizationRepo    = repository.NewAuthorizationRepository(a.db)
+		authorizationService = service.NewAuthorizationService(authorizationRepo)
+
+		// Transaction
+		txManager = repository.NewTransactionManager(a.db)
+
+		// Outbox
+		outboxRepo    = repository.NewOutboxRepository(a.db)
+		outboxService = service.NewOutboxService(outboxRepo, txManager, a.logger, a.rabbitConn)
+
+		// Task ledger
+		taskRepo = repository.NewTaskRepository(a.db)
+
+		// Consumer
+		consumerRepo = repository.NewConsumerRepository(a.db)
+
+		// Task admin
+		taskService = service.NewTaskService(taskRepo, consumerRepo, a.logger, a.rabbitConn)
+		taskHandler = handler.NewTaskHandler(taskService)
+
+		// Summary
+		summaryRepo    = repository.NewSummaryRepository(a.db)
+		summaryService = service.NewSummaryService(summaryRepo)
+		summaryHandler = handler.NewSummaryHandler(summaryService)
+
+		// Health
+		healthRepo = repository.NewHealthRepository(a.db)
+
+		// Log level, tiap proses (worker / api) punya level sendiri
+		logLevelService = service.NewLogLevelService(a.logLevel, a.logger)
+		logLevelHandler = handler.NewLogLevelHandler(logLevelService)
+	)
+
+	server, err := newHTTPServer(a.cfg, a.logger)
+	if err != nil {
+		return err
+	}
+	routes.Metrics(server)
+	routes.LogLevel(server, logLevelHandler, a.jwtService, apiKeyService, authorizationService, userService)
+
+	if runWorker {
+		consumerService := service.NewConsumerService(consumerRepo, outboxRepo, taskRepo, txManager, a.logger, a.rabbitConn, a.jwtService, a.grpcClient, a.cfg.Worker.TaskTimeout)
+
+		// Consumer controller, berjalan di ctx aplikasi bukan ctx request
+		consumerControllerService := service.NewConsumerControllerService(ctx, consumerService, a.logger)
+		consumerHandler := handler.NewConsumerHandler(consumerControllerService)
+
+		healthService := service.NewHealthService(healthRepo, consumerService, consumerControllerService, a.rabbitConn, a.grpcClient)
+		routes.Health(server, handler.NewHealthHandler(healthService))
+		routes.Consumer(server, consumerHandler, a.jwtService, apiKeyService, authorizationService, userService)
+
+		// jalankan consumer
+		a.logger.Info("starting RabbitMQ consumer listener...")
+		if err := consumerControllerService.Start(ctx); err != nil {
+			return err
+		}
+		// saat shutdown tunggu task yang sedang diproses selesai sebelum koneksi ditutup
+		defer func() {
+			stopCtx, cancel := context.WithTimeout(context.Background(), shutdownTimeout)
+			defer cancel()
+			if err := consumerControllerService.Stop(stopCtx); err != nil && !errors.Is(err, dto.ErrConsumerNotRunning) {
+				a.logger.Error("failed to stop consumer", zap.Error(err))
+			}
+		}()
+
+		// jalankan relay outbox event
+		go func() {
+			a.logger.Info("starting outbox relay...")
+			if err := outboxService.RelayOutboxEvents(ctx); err != nil {
+				a.logger.Error("outbox relay stopped", zap.Error(err))
+			}
+		}()
+	} else {
+		healthService := service.NewHealthService(healthRepo, nil, nil, a.rabbitConn, nil)
+		routes.Health(server, handler.NewHealthHandler(healthService))
+	}
+
+	if runAPI {
+		routes.Auth(server, authHandler, a.jwtService, userService)
+		routes.Task(server, taskHandler, a.jwtService, apiKeyService, authorizationService, userService)
+		routes.Summary(server, summaryHandler, a.jwtService, authorizationService, userService)
+
+		server.Static("/uploads", "./uploads")
+	}
+
+	return listenAndServe(ctx, a.cfg.ListenAddr(), server, a.logger)
+}
+
+// newHTTPServer menyiapkan gin engine dengan middleware yang sama untuk semua mode.
+func newHTTPServer(cfg *config.Config, logger *zap.Logger) (*gin.Engine, error) {
+	server := gin.New()
+	// service menerima *gin.Context sebagai context.Context; fallback membuat Value/Done
+	// membaca context request (correlation id, pembatalan saat client terputus)
+	server.ContextWithFallback = true
+	server.Use(
+		middleware.RequestID(logger),
+		middleware.AccessLog(logger),
+		middleware.Recovery(logger),
+	)
+	uploads := cfg.CORS.Uploads
+	cors, err := middleware.CORSMiddleware(
+		middleware.CORSConfig{
+			AllowedOrigins:   cfg.CORS.AllowedOrigins,
+			AllowedHeaders:   cfg.CORS.AllowedHeaders,
+			AllowedMethods:   cfg.CORS.AllowedMethods,
+			ExposedHeaders:   cfg.CORS.ExposedHeaders,
+			AllowCredentials: cfg.CORS.AllowCredentials,
+			MaxAge:           cfg.CORS.MaxAge,
+		},
+		map[string]middleware.CORSConfig{
+			"/uploads": {
+				AllowedOrigins:   uploads.AllowedOrigins,
+				AllowedHeaders:   uploads.AllowedHeaders,
+				AllowedMethods:   uploads.AllowedMethods,
+				ExposedHeaders:   uploads.ExposedHeaders,
+				AllowCredentials: uploads.AllowCredentials,
+				MaxAge:           uploads.MaxAge,
+			},
+		},
+	)
+	if err != nil {
+		return nil, fmt.Errorf("invalid cors config: %w", err)
+	}
+	server.Use(cors)
+
+	return server, nil
+}
+
+// listenAndServe berhenti dengan graceful shutdown saat ctx dibatalkan (SIGINT/SIGTERM).
+func listenAndServe(ctx context.Context, addr string, handler http.Handler, logger *zap.Logger) error {
+	srv := &http.Server{
+		Addr:              addr,
+		Handler:           handler,
+		ReadHeaderTimeout: 10 * time.Second,
+	}
+
+	errCh := make(chan error, 1)
+	go func() {
+		logger.Info("http server listening", zap.String("addr", addr))
+		errCh <- srv.ListenAndServe()
+	}()
+
+	select {
+	case err := <-errCh:
+		if errors.Is(err, http.ErrServerClosed) {
+			return nil
+		}
+		return err
+	case <-ctx.Done():
+	}
+
+	logger.Info("shutting down http server...")
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), shutdownTimeout)
+	defer cancel()
+
+	return srv.Shutdown(shutdownCtx)
+}
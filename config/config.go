@@ -0,0 +1,513 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"slices"
+	"strconv"
+	"time"
+
+	"github.com/Amierza/worker-service/constants"
+	amqp "github.com/rabbitmq/amqp091-go"
+	"go.uber.org/zap/zapcore"
+)
+
+type (
+	// Config adalah seluruh konfigurasi aplikasi. Tag env menentukan nama environment variable
+	// (beserta varian <ENV>_FILE), tag secret menandai nilai yang disensor saat dicetak.
+	Config struct {
+		App       AppConfig       `yaml:"app"`
+		Database  DatabaseConfig  `yaml:"database"`
+		RabbitMQ  RabbitMQConfig  `yaml:"rabbitmq"`
+		AIService AIServiceConfig `yaml:"ai_service"`
+		Worker    WorkerConfig    `yaml:"worker"`
+		JWT       JWTConfig       `yaml:"jwt"`
+		Log       LogConfig       `yaml:"log"`
+		Tracing   TracingConfig   `yaml:"tracing"`
+		CORS      CORSConfig      `yaml:"cors"`
+	}
+
+	AppConfig struct {
+		Env  string `yaml:"env" env:"APP_ENV"`
+		Port int    `yaml:"port" env:"PORT"`
+	}
+
+	DatabaseConfig struct {
+		Host     string `yaml:"host" env:"DB_HOST"`
+		Port     int    `yaml:"port" env:"DB_PORT"`
+		User     string `yaml:"user" env:"DB_USER"`
+		Password string `yaml:"password" env:"DB_PASS" secret:"true"`
+		Name     string `yaml:"name" env:"DB_NAME"`
+		SSLMode  string `yaml:"sslmode" env:"DB_SSLMODE"`
+		TimeZone string `yaml:"timezone" env:"DB_TIMEZONE"`
+	}
+
+	RabbitMQConfig struct {
+		URL string `yaml:"url" env:"RABBITMQ_URL" secret:"url"`
+	}
+
+	AIServiceConfig struct {
+		GRPCAddr string `yaml:"grpc_addr" env:"AI_SERVICE_GRPC_ADDR"`
+	}
+
+	// WorkerConfig; TaskTimeout membatasi pemrosesan satu task agar AI service yang hang tidak
+	// menahan consumer (Qos 1) selamanya. Task yang melewatinya di-retry seperti error sementara.
+	WorkerConfig struct {
+		TaskTimeout time.Duration `yaml:"task_timeout" env:"WORKER_TASK_TIMEOUT"`
+	}
+
+	// JWTConfig; Issuer harus sama dengan backend utama. LegacyTokens (opt-in) menerima token
+	// backend utama yang belum membawa token_type/jti/fid; token seperti itu tidak bisa dibedakan
+	// dari refresh token dan tidak bisa dicabut, sehingga di luar localhost wajib diberi batas
+	// LegacyTokensUntil (YYYY-MM-DD, UTC) dan otomatis ditolak setelah tanggal tersebut.
+	JWTConfig struct {
+		Secret            string        `yaml:"secret" env:"JWT_SECRET" secret:"true"`
+		Issuer            string        `yaml:"issuer" env:"JWT_ISSUER"`
+		LegacyTokens      bool          `yaml:"legacy_tokens" env:"JWT_LEGACY_TOKENS"`
+		LegacyTokensUntil string        `yaml:"legacy_tokens_until" env:"JWT_LEGACY_TOKENS_UNTIL"`
+		AccessTTL         time.Duration `yaml:"access_ttl" env:"JWT_ACCESS_TTL"`
+		RefreshTTL        time.Duration `yaml:"refresh_ttl" env:"JWT_REFRESH_TTL"`
+		JWKSFile          string        `yaml:"jwks_file" env:"JWT_JWKS_FILE"`
+		JWKSURL           string        `yaml:"jwks_url" env:"JWT_JWKS_URL"`
+		JWKSCacheTTL      time.Duration `yaml:"jwks_cache_ttl" env:"JWT_JWKS_CACHE_TTL"`
+	}
+
+	// LogConfig; mode, level & encoding yang kosong diturunkan dari APP_ENV (lihat applyDerived).
+	LogConfig struct {
+		Mode     string            `yaml:"mode" env:"LOG_MODE"`
+		Level    string            `yaml:"level" env:"LOG_LEVEL"`
+		Encoding string            `yaml:"encoding" env:"LOG_ENCODING"`
+		Sampling LogSamplingConfig `yaml:"sampling"`
+		File     LogFileConfig     `yaml:"file"`
+	}
+
+	// LogSamplingConfig: tiap detik, Initial log pertama dengan pesan & level sama ditulis,
+	// selanjutnya hanya setiap log ke-Thereafter.
+	LogSamplingConfig struct {
+		Enabled    bool `yaml:"enabled" env:"LOG_SAMPLING_ENABLED"`
+		Initial    int  `yaml:"initial" env:"LOG_SAMPLING_INITIAL"`
+		Thereafter int  `yaml:"thereafter" env:"LOG_SAMPLING_THEREAFTER"`
+	}
+
+	// LogFileConfig mengaktifkan output file berotasi (JSON) selain stderr jika Path diisi.
+	LogFileConfig struct {
+		Path       string `yaml:"path" env:"LOG_FILE_PATH"`
+		MaxSizeMB  int    `yaml:"max_size_mb" env:"LOG_FILE_MAX_SIZE_MB"`
+		MaxBackups int    `yaml:"max_backups" env:"LOG_FILE_MAX_BACKUPS"`
+		MaxAgeDays int    `yaml:"max_age_days" env:"LOG_FILE_MAX_AGE_DAYS"`
+		Compress   bool   `yaml:"compress" env:"LOG_FILE_COMPRESS"`
+	}
+
+	// TracingConfig; exporter none tetap memasang propagator W3C sehingga trace context dari
+	// AMQP diteruskan ke AI service walaupun span worker ini tidak diekspor.
+	TracingConfig struct {
+		Exporter     string  `yaml:"exporter" env:"TRACING_EXPORTER"`
+		ServiceName  string  `yaml:"service_name" env:"TRACING_SERVICE_NAME"`
+		OTLPEndpoint string  `yaml:"otlp_endpoint" env:"TRACING_OTLP_ENDPOINT"`
+		OTLPInsecure bool    `yaml:"otlp_insecure" env:"TRACING_OTLP_INSECURE"`
+		FilePath     string  `yaml:"file_path" env:"TRACING_FILE_PATH"`
+		SampleRatio  float64 `yaml:"sample_ratio" env:"TRACING_SAMPLE_RATIO"`
+	}
+
+	// CORSConfig adalah kebijakan CORS API; Uploads dipakai untuk /uploads. Origin persis
+	// (https://app.example.com), wildcard subdomain (https://*.example.com) atau "*", daftar dipisah koma di env.
+	CORSConfig struct {
+		AllowedOrigins   []string          `yaml:"allowed_origins" env:"CORS_ALLOWED_ORIGINS"`
+		AllowedHeaders   []string          `yaml:"allowed_headers" env:"CORS_ALLOWED_HEADERS"`
+		AllowedMethods   []string          `yaml:"allowed_methods" env:"CORS_ALLOWED_METHODS"`
+		ExposedHeaders   []string          `yaml:"exposed_headers" env:"CORS_EXPOSED_HEADERS"`
+		AllowCredentials bool              `yaml:"allow_credentials" env:"CORS_ALLOW_CREDENTIALS"`
+		MaxAge           time.Duration     `yaml:"max_age" env:"CORS_MAX_AGE"`
+		Uploads          CORSUploadsConfig `yaml:"uploads"`
+	}
+
+	CORSUploadsConfig struct {
+		AllowedOrigins   []string      `yaml:"allowed_origins" env:"CORS_UPLOADS_ALLOWED_ORIGINS"`
+		AllowedHeaders   []string      `yaml:"allowed_headers" env:"CORS_UPLOADS_ALLOWED_HEADERS"`
+		AllowedMethods   []string      `yaml:"allowed_methods" env:"CORS_UPLOADS_ALLOWED_METHODS"`
+		ExposedHeaders   []string      `yaml:"exposed_headers" env:"CORS_UPLOADS_EXPOSED_HEADERS"`
+		AllowCredentials bool          `yaml:"allow_credentials" env:"CORS_UPLOADS_ALLOW_CREDENTIALS"`
+		MaxAge           time.Duration `yaml:"max_age" env:"CORS_UPLOADS_MAX_AGE"`
+	}
+)
+
+// Requirement menandai bagian konfigurasi yang dibutuhkan sebuah mode/perintah, sehingga
+// misalnya `migrate` tidak gagal hanya karena RABBITMQ_URL kosong.
+type Requirement uint8
+
+const (
+	RequireDatabase Requirement = 1 << iota
+	RequireRabbitMQ
+	RequireAIService
+	RequireJWT
+
+	RequireAll = RequireDatabase | RequireRabbitMQ | RequireAIService | RequireJWT
+)
+
+// Default mengembalikan nilai bawaan sebelum ditimpa file YAML & environment.
+func Default() *Config {
+	return &Config{
+		App: AppConfig{
+			Env:  constants.ENUM_RUN_LOCALHOST,
+			Port: 8000,
+		},
+		Database: DatabaseConfig{
+			Port:     5432,
+			TimeZone: constants.ENUM_TIMEZONE_DEFAULT,
+		},
+		AIService: AIServiceConfig{
+			GRPCAddr: "localhost:50051",
+		},
+		Worker: WorkerConfig{
+			TaskTimeout: constants.ENUM_TASK_TIMEOUT * time.Second,
+		},
+		JWT: JWTConfig{
+			Issuer:       constants.ENUM_JWT_DEFAULT_ISSUER,
+			AccessTTL:    constants.ENUM_JWT_ACCESS_TTL * time.Second,
+			RefreshTTL:   constants.ENUM_JWT_REFRESH_TTL * time.Second,
+			JWKSCacheTTL: constants.ENUM_JWKS_CACHE_TTL * time.Second,
+		},
+		Log: LogConfig{
+			Sampling: LogSamplingConfig{
+				Enabled:    true,
+				Initial:    100,
+				Thereafter: 100,
+			},
+			File: LogFileConfig{
+				MaxSizeMB:  100,
+				MaxBackups: 7,
+				MaxAgeDays: 30,
+				Compress:   true,
+			},
+		},
+		Tracing: TracingConfig{
+			Exporter:     constants.ENUM_TRACING_EXPORTER_NONE,
+			ServiceName:  "worker-service",
+			OTLPEndpoint: "localhost:4317",
+			SampleRatio:  1,
+		},
+		CORS: CORSConfig{
+			AllowedHeaders:   []string{"Content-Type", "Content-Length", "Accept-Encoding", "Authorization", "Accept", "Origin", "Cache-Control", "X-Requested-With", constants.ENUM_HEADER_API_KEY},
+			AllowedMethods:   []string{"GET", "POST", "PUT", "PATCH", "DELETE", "HEAD", "OPTIONS"},
+			AllowCredentials: true,
+			MaxAge:           12 * time.Hour,
+			Uploads: CORSUploadsConfig{
+				AllowedOrigins: []string{"*"},
+				AllowedMethods: []string{"GET", "HEAD", "OPTIONS"},
+				MaxAge:         24 * time.Hour,
+			},
+		},
+	}
+}
+
+// applyDerived mengisi nilai yang bergantung pada field lain setelah semua sumber dibaca:
+// localhost memakai log development (console, debug), selain itu production (json, info).
+func (c *Config) applyDerived() {
+	if c.Log.Mode == "" {
+		c.Log.Mode = constants.ENUM_LOG_MODE_PRODUCTION
+		if c.IsLocalhost() {
+			c.Log.Mode = constants.ENUM_LOG_MODE_DEVELOPMENT
+		}
+	}
+
+	development := c.Log.Mode == constants.ENUM_LOG_MODE_DEVELOPMENT
+	if c.Log.Level == "" {
+		c.Log.Level = "info"
+		if development {
+			c.Log.Level = "debug"
+		}
+	}
+	if c.Log.Encoding == "" {
+		c.Log.Encoding = constants.ENUM_LOG_ENCODING_JSON
+		if development {
+			c.Log.Encoding = constants.ENUM_LOG_ENCODING_CONSOLE
+		}
+	}
+}
+
+func (c *Config) IsLocalhost() bool {
+	return c.App.Env == constants.ENUM_RUN_LOCALHOST
+}
+
+// ListenAddr hanya bind ke loopback saat localhost.
+func (c *Config) ListenAddr() string {
+	if c.IsLocalhost() {
+		return "127.0.0.1:" + strconv.Itoa(c.App.Port)
+	}
+
+	return ":" + strconv.Itoa(c.App.Port)
+}
+
+// DSN menyusun connection string postgres (key=value) dari konfigurasi database.
+func (d DatabaseConfig) DSN() string {
+	dsn := fmt.Sprintf("host=%s user=%s password=%s dbname=%s port=%d TimeZone=%s",
+		quoteDSNValue(d.Host), quoteDSNValue(d.User), quoteDSNValue(d.Password), quoteDSNValue(d.Name), d.Port, quoteDSNValue(d.TimeZone))
+	if d.SSLMode != "" {
+		dsn += " sslmode=" + quoteDSNValue(d.SSLMode)
+	}
+
+	return dsn
+}
+
+// RedactedDSN sama dengan DSN tetapi password disensor, aman untuk dicatat di log.
+func (d DatabaseConfig) RedactedDSN() string {
+	if d.Password != "" {
+		d.Password = redactedValue
+	}
+
+	return d.DSN()
+}
+
+// quoteDSNValue mengutip nilai yang kosong atau mengandung spasi/kutip sesuai format libpq.
+func quoteDSNValue(value string) string {
+	needQuote := value == ""
+	for _, r := range value {
+		if r == ' ' || r == '\'' || r == '\\' {
+			needQuote = true
+			break
+		}
+	}
+	if !needQuote {
+		return value
+	}
+
+	escaped := make([]rune, 0, len(value)+2)
+	for _, r := range value {
+		if r == '\'' || r == '\\' {
+			escaped = append(escaped, '\\')
+		}
+		escaped = append(escaped, r)
+	}
+
+	return "'" + string(escaped) + "'"
+}
+
+// Validate mengumpulkan semua kesalahan konfigurasi sekaligus agar bisa diperbaiki dalam satu kali start.
+// Bagian app selalu divalidasi, bagian lain hanya jika termasuk dalam required.
+func (c *Config) Validate(required Requirement) error {
+	var errs []error
+
+	validEnvs := []string{constants.ENUM_RUN_PRODUCTION, constants.ENUM_RUN_TESTING, constants.ENUM_RUN_LOCALHOST}
+	if !slices.Contains(validEnvs, c.App.Env) {
+		errs = append(errs, invalidField("app.env", "APP_ENV", "must be one of %v, got %q", validEnvs, c.App.Env))
+	}
+	if c.App.Port < 1 || c.App.Port > 65535 {
+		errs = append(errs, invalidField("app.port", "PORT", "must be between 1 and 65535, got %d", c.App.Port))
+	}
+
+	errs = append(errs, c.Log.validate()...)
+	errs = append(errs, c.Tracing.validate()...)
+	errs = append(errs, validateCORS("cors", "CORS", c.CORS.AllowedOrigins, c.CORS.AllowCredentials, c.CORS.MaxAge)...)
+	errs = append(errs, validateCORS("cors.uploads", "CORS_UPLOADS", c.CORS.Uploads.AllowedOrigins, c.CORS.Uploads.AllowCredentials, c.CORS.Uploads.MaxAge)...)
+
+	if required&RequireDatabase != 0 {
+		errs = append(errs, c.Database.validate()...)
+	}
+	if required&RequireRabbitMQ != 0 {
+		errs = append(errs, c.RabbitMQ.validate()...)
+		errs = append(errs, c.Worker.validate()...)
+	}
+	if required&RequireAIService != 0 {
+		errs = append(errs, c.AIService.validate()...)
+	}
+	if required&RequireJWT != 0 {
+		errs = append(errs, c.JWT.validate(c.IsLocalhost())...)
+	}
+
+	if len(errs) > 0 {
+		return fmt.Errorf("invalid configuration:\n%w", errors.Join(errs...))
+	}
+
+	return nil
+}
+
+func invalidField(field, env, format string, args ...any) error {
+	return fmt.Errorf("%s (%s): %s", field, env, fmt.Sprintf(format, args...))
+}
+
+func (d DatabaseConfig) validate() []error {
+	var errs []error
+	if d.Host == "" {
+		errs = append(errs, invalidField("database.host", "DB_HOST", "is required"))
+	}
+	if d.Port < 1 || d.Port > 65535 {
+		errs = append(errs, invalidField("database.port", "DB_PORT", "must be between 1 and 65535, got %d", d.Port))
+	}
+	if d.User == "" {
+		errs = append(errs, invalidField("database.user", "DB_USER", "is required"))
+	}
+	if d.Name == "" {
+		errs = append(errs, invalidField("database.name", "DB_NAME", "is required"))
+	}
+	if _, err := time.LoadLocation(d.TimeZone); err != nil {
+		errs = append(errs, invalidField("database.timezone", "DB_TIMEZONE", "unknown time zone %q", d.TimeZone))
+	}
+
+	return errs
+}
+
+func (r RabbitMQConfig) validate() []error {
+	if r.URL == "" {
+		return []error{invalidField("rabbitmq.url", "RABBITMQ_URL", "is required")}
+	}
+	if _, err := amqp.ParseURI(r.URL); err != nil {
+		return []error{invalidField("rabbitmq.url", "RABBITMQ_URL", "invalid AMQP URI: %v", err)}
+	}
+
+	return nil
+}
+
+func (w WorkerConfig) validate() []error {
+	if w.TaskTimeout <= 0 {
+		return []error{invalidField("worker.task_timeout", "WORKER_TASK_TIMEOUT", "must be a positive duration")}
+	}
+
+	return nil
+}
+
+func (a AIServiceConfig) validate() []error {
+	if a.GRPCAddr == "" {
+		return []error{invalidField("ai_service.grpc_addr", "AI_SERVICE_GRPC_ADDR", "is required")}
+	}
+
+	return nil
+}
+
+func (j JWTConfig) validate(isLocalhost bool) []error {
+	var errs []error
+	if j.Issuer == "" {
+		errs = append(errs, invalidField("jwt.issuer", "JWT_ISSUER", "is required"))
+	}
+	if j.AccessTTL <= 0 {
+		errs = append(errs, invalidField("jwt.access_ttl", "JWT_ACCESS_TTL", "must be a positive duration"))
+	}
+	if j.RefreshTTL <= 0 {
+		errs = append(errs, invalidField("jwt.refresh_ttl", "JWT_REFRESH_TTL", "must be a positive duration"))
+	}
+	if j.JWKSFile != "" && j.JWKSURL != "" {
+		errs = append(errs, invalidField("jwt.jwks_file", "JWT_JWKS_FILE", "cannot be combined with JWT_JWKS_URL"))
+	}
+	if j.JWKSURL != "" {
+		if u, err := url.Parse(j.JWKSURL); err != nil || u.Scheme == "" || u.Host == "" {
+			errs = append(errs, invalidField("jwt.jwks_url", "JWT_JWKS_URL", "must be an absolute URL"))
+		}
+		if j.JWKSCacheTTL <= 0 {
+			errs = append(errs, invalidField("jwt.jwks_cache_ttl", "JWT_JWKS_CACHE_TTL", "must be a positive duration"))
+		}
+	}
+
+	if j.LegacyTokens {
+		until, err := j.LegacyTokensDeadline()
+		switch {
+		case err != nil:
+			errs = append(errs, invalidField("jwt.legacy_tokens_until", "JWT_LEGACY_TOKENS_UNTIL", "must be a date (YYYY-MM-DD), got %q", j.LegacyTokensUntil))
+		case until.IsZero() && !isLocalhost:
+			errs = append(errs, invalidField("jwt.legacy_tokens_until", "JWT_LEGACY_TOKENS_UNTIL", "is required when JWT_LEGACY_TOKENS=true outside localhost"))
+		case !until.IsZero() && !time.Now().Before(until):
+			errs = append(errs, invalidField("jwt.legacy_tokens_until", "JWT_LEGACY_TOKENS_UNTIL", "%s has passed, disable JWT_LEGACY_TOKENS", j.LegacyTokensUntil))
+		}
+	}
+
+	// secret default hanya boleh dipakai di localhost, kecuali token diverifikasi lewat JWKS
+	usesSecret := j.JWKSFile == "" && j.JWKSURL == ""
+	if usesSecret && !isLocalhost && (j.Secret == "" || j.Secret == constants.ENUM_JWT_DEFAULT_SECRET) {
+		errs = append(errs, invalidField("jwt.secret", "JWT_SECRET", "must be set to a non-default value outside localhost"))
+	}
+
+	return errs
+}
+
+// LegacyTokensDeadline mengembalikan waktu nol jika LegacyTokensUntil kosong (tanpa batas, hanya lolos di localhost).
+func (j JWTConfig) LegacyTokensDeadline() (time.Time, error) {
+	if j.LegacyTokensUntil == "" {
+		return time.Time{}, nil
+	}
+
+	return time.Parse(time.DateOnly, j.LegacyTokensUntil)
+}
+
+func (t TracingConfig) validate() []error {
+	var errs []error
+
+	exporters := []string{constants.ENUM_TRACING_EXPORTER_NONE, constants.ENUM_TRACING_EXPORTER_OTLP, constants.ENUM_TRACING_EXPORTER_STDOUT, constants.ENUM_TRACING_EXPORTER_FILE}
+	if !slices.Contains(exporters, t.Exporter) {
+		errs = append(errs, invalidField("tracing.exporter", "TRACING_EXPORTER", "must be one of %v, got %q", exporters, t.Exporter))
+	}
+	if t.Exporter != constants.ENUM_TRACING_EXPORTER_NONE && t.ServiceName == "" {
+		errs = append(errs, invalidField("tracing.service_name", "TRACING_SERVICE_NAME", "is required"))
+	}
+	if t.Exporter == constants.ENUM_TRACING_EXPORTER_OTLP && t.OTLPEndpoint == "" {
+		errs = append(errs, invalidField("tracing.otlp_endpoint", "TRACING_OTLP_ENDPOINT", "is required for otlp exporter"))
+	}
+	if t.Exporter == constants.ENUM_TRACING_EXPORTER_FILE && t.FilePath == "" {
+		errs = append(errs, invalidField("tracing.file_path", "TRACING_FILE_PATH", "is required for file exporter"))
+	}
+	if t.SampleRatio < 0 || t.SampleRatio > 1 {
+		errs = append(errs, invalidField("tracing.sample_ratio", "TRACING_SAMPLE_RATIO", "must be between 0 and 1, got %v", t.SampleRatio))
+	}
+
+	return errs
+}
+
+// validateCORS menolak origin "*" bersama credentials karena sama saja mengizinkan semua origin
+// membaca respons ber-cookie. field & env adalah prefix, mis. cors.uploads & CORS_UPLOADS.
+func validateCORS(field, env string, origins []string, allowCredentials bool, maxAge time.Duration) []error {
+	var errs []error
+
+	for _, origin := range origins {
+		if origin == "*" {
+			if allowCredentials {
+				errs = append(errs, invalidField(field+".allowed_origins", env+"_ALLOWED_ORIGINS", `"*" cannot be combined with %s_ALLOW_CREDENTIALS=true`, env))
+			}
+			continue
+		}
+		if u, err := url.Parse(origin); err != nil || u.Scheme == "" || u.Host == "" || u.Path != "" {
+			errs = append(errs, invalidField(field+".allowed_origins", env+"_ALLOWED_ORIGINS", `must be "*" or scheme://host[:port], got %q`, origin))
+		}
+	}
+	if maxAge < 0 {
+		errs = append(errs, invalidField(field+".max_age", env+"_MAX_AGE", "must not be negative"))
+	}
+
+	return errs
+}
+
+func (l LogConfig) validate() []error {
+	var errs []error
+
+	modes := []string{constants.ENUM_LOG_MODE_DEVELOPMENT, constants.ENUM_LOG_MODE_PRODUCTION}
+	if !slices.Contains(modes, l.Mode) {
+		errs = append(errs, invalidField("log.mode", "LOG_MODE", "must be one of %v, got %q", modes, l.Mode))
+	}
+	if _, err := zapcore.ParseLevel(l.Level); err != nil {
+		errs = append(errs, invalidField("log.level", "LOG_LEVEL", "unknown level %q", l.Level))
+	}
+	encodings := []string{constants.ENUM_LOG_ENCODING_JSON, constants.ENUM_LOG_ENCODING_CONSOLE}
+	if !slices.Contains(encodings, l.Encoding) {
+		errs = append(errs, invalidField("log.encoding", "LOG_ENCODING", "must be one of %v, got %q", encodings, l.Encoding))
+	}
+
+	if l.Sampling.Enabled {
+		if l.Sampling.Initial < 1 {
+			errs = append(errs, invalidField("log.sampling.initial", "LOG_SAMPLING_INITIAL", "must be at least 1"))
+		}
+		if l.Sampling.Thereafter < 1 {
+			errs = append(errs, invalidField("log.sampling.thereafter", "LOG_SAMPLING_THEREAFTER", "must be at least 1"))
+		}
+	}
+
+	if l.File.Path != "" {
+		if l.File.MaxSizeMB < 1 {
+			errs = append(errs, invalidField("log.file.max_size_mb", "LOG_FILE_MAX_SIZE_MB", "must be at least 1"))
+		}
+		if l.File.MaxBackups < 0 {
+			errs = append(errs, invalidField("log.file.max_backups", "LOG_FILE_MAX_BACKUPS", "must not be negative"))
+		}
+		if l.File.MaxAgeDays < 0 {
+			errs = append(errs, invalidField("log.file.max_age_days", "LOG_FILE_MAX_AGE_DAYS", "must not be negative"))
+		}
+	}
+
+	return errs
+}
@@ -3,38 +3,38 @@ package database
 import (
 	"fmt"
 	"log"
-	"os"
+	"time"
 
+	"github.com/Amierza/worker-service/config"
 	"github.com/Amierza/worker-service/constants"
-	"github.com/joho/godotenv"
+	"github.com/Amierza/worker-service/logger"
+	"go.uber.org/zap"
 	"gorm.io/driver/postgres"
 	"gorm.io/gorm"
+	otelgorm "gorm.io/plugin/opentelemetry/tracing"
 )
 
-func SetUpPostgreSQLConnection() *gorm.DB {
-	if os.Getenv("APP_ENV") != constants.ENUM_RUN_PRODUCTION {
-		if err := godotenv.Load(".env"); err != nil {
-			panic(fmt.Errorf("failed to laod .env file: %v", err))
-		}
-	}
-
-	dbHost := os.Getenv("DB_HOST")
-	dbUser := os.Getenv("DB_USER")
-	dbPass := os.Getenv("DB_PASS")
-	dbName := os.Getenv("DB_NAME")
-	dbPort := os.Getenv("DB_PORT")
-
-	dsn := fmt.Sprintf("host=%v, user=%v password=%v dbname=%v port=%v TimeZone=Asia/Jakarta", dbHost, dbUser, dbPass, dbName, dbPort)
-	log.Printf("connecting to postgres: %v", dsn)
+// SetUpPostgreSQLConnection memakai zap untuk log statement GORM, lihat logger.GormLogger.
+func SetUpPostgreSQLConnection(cfg config.DatabaseConfig, zapLogger *zap.Logger) *gorm.DB {
+	dsn := cfg.DSN()
+	log.Printf("connecting to postgres: %v", cfg.RedactedDSN())
 
 	db, err := gorm.Open(postgres.New(postgres.Config{
 		DSN:                  dsn,
 		PreferSimpleProtocol: true,
-	}), &gorm.Config{})
+	}), &gorm.Config{
+		Logger: logger.NewGormLogger(zapLogger, constants.ENUM_DB_SLOW_QUERY_THRESHOLD*time.Millisecond),
+	})
 	if err != nil {
 		panic(fmt.Errorf("failed to connect postgres: %v", err))
 	}
 
+	// span per statement sebagai anak span di ctx; SQL dicatat tanpa nilai parameter dan
+	// metrik pool tidak diekspor lewat OTel karena metrik sudah ditangani Prometheus
+	if err := db.Use(otelgorm.NewPlugin(otelgorm.WithoutQueryVariables(), otelgorm.WithoutMetrics())); err != nil {
+		panic(fmt.Errorf("failed to register gorm tracing plugin: %v", err))
+	}
+
 	log.Println("postgres connection established")
 	return db
 }
@@ -0,0 +1,214 @@
+package config
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/joho/godotenv"
+	"gopkg.in/yaml.v3"
+)
+
+const (
+	// ConfigFileEnv menunjuk file YAML opsional, nilainya ditimpa environment variable.
+	ConfigFileEnv = "CONFIG_FILE"
+
+	// fileSuffix dipakai untuk secret yang dibaca dari file, mis. DB_PASS_FILE=/run/secrets/db_pass.
+	fileSuffix = "_FILE"
+
+	dotEnvFile = ".env"
+)
+
+var durationType = reflect.TypeOf(time.Duration(0))
+
+// Load membaca konfigurasi dengan urutan prioritas: nilai default < file YAML (path atau
+// CONFIG_FILE) < environment variable (termasuk .env dan <ENV>_FILE). Hasilnya belum divalidasi.
+func Load(path string) (*Config, error) {
+	if err := godotenv.Load(dotEnvFile); err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return nil, fmt.Errorf("failed to load %s: %w", dotEnvFile, err)
+	}
+
+	cfg := Default()
+
+	if path == "" {
+		path = os.Getenv(ConfigFileEnv)
+	}
+	if path != "" {
+		if err := loadYAML(path, cfg); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := applyEnv(reflect.ValueOf(cfg).Elem(), ""); err != nil {
+		return nil, err
+	}
+	cfg.applyDerived()
+
+	return cfg, nil
+}
+
+// New memuat lalu memvalidasi bagian konfigurasi yang dibutuhkan, dipakai saat startup.
+func New(path string, required Requirement) (*Config, error) {
+	cfg, err := Load(path)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := cfg.Validate(required); err != nil {
+		return nil, err
+	}
+
+	return cfg, nil
+}
+
+func loadYAML(path string, cfg *Config) error {
+	raw, err := os.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("failed to read config file: %w", err)
+	}
+
+	decoder := yaml.NewDecoder(bytes.NewReader(raw))
+	// key yang salah ketik lebih baik gagal daripada diam-diam diabaikan
+	decoder.KnownFields(true)
+	if err := decoder.Decode(cfg); err != nil && !errors.Is(err, io.EOF) {
+		return fmt.Errorf("failed to parse config file %s: %w", path, err)
+	}
+
+	return nil
+}
+
+// applyEnv menimpa setiap field bertag env dengan nilai dari <ENV> atau isi file <ENV>_FILE.
+func applyEnv(v reflect.Value, prefix string) error {
+	var errs []error
+
+	t := v.Type()
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		value := v.Field(i)
+		name := fieldName(prefix, field)
+
+		if field.Type.Kind() == reflect.Struct && field.Type != durationType {
+			if err := applyEnv(value, name); err != nil {
+				errs = append(errs, err)
+			}
+			continue
+		}
+
+		key := field.Tag.Get("env")
+		if key == "" {
+			continue
+		}
+
+		raw, ok, err := lookupEnv(key)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("%s (%s): %w", name, key, err))
+			continue
+		}
+		if !ok {
+			continue
+		}
+
+		if err := setValue(value, raw); err != nil {
+			errs = append(errs, fmt.Errorf("%s (%s): %w", name, key, err))
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+// lookupEnv membaca <key> atau <key>_FILE; keduanya tidak boleh diisi bersamaan. Variabel
+// yang kosong dianggap tidak diisi agar template .env tidak menimpa default.
+func lookupEnv(key string) (string, bool, error) {
+	value := strings.TrimSpace(os.Getenv(key))
+	path := strings.TrimSpace(os.Getenv(key + fileSuffix))
+	hasValue, hasFile := value != "", path != ""
+
+	if hasValue && hasFile {
+		return "", false, fmt.Errorf("only one of %s and %s%s may be set", key, key, fileSuffix)
+	}
+
+	if hasFile {
+		raw, err := os.ReadFile(path)
+		if err != nil {
+			return "", false, fmt.Errorf("failed to read %s%s: %w", key, fileSuffix, err)
+		}
+		// newline di akhir file secret (echo, editor) bukan bagian dari nilai
+		return strings.TrimRight(string(raw), "\r\n"), true, nil
+	}
+
+	return value, hasValue, nil
+}
+
+func setValue(value reflect.Value, raw string) error {
+	if value.Type() == durationType {
+		d, err := time.ParseDuration(raw)
+		if err != nil {
+			return fmt.Errorf("invalid duration %q", raw)
+		}
+		value.SetInt(int64(d))
+		return nil
+	}
+
+	switch value.Kind() {
+	case reflect.String:
+		value.SetString(raw)
+	case reflect.Int:
+		n, err := strconv.Atoi(raw)
+		if err != nil {
+			return fmt.Errorf("invalid integer %q", raw)
+		}
+		value.SetInt(int64(n))
+	case reflect.Float64:
+		f, err := strconv.ParseFloat(raw, 64)
+		if err != nil {
+			return fmt.Errorf("invalid number %q", raw)
+		}
+		value.SetFloat(f)
+	case reflect.Bool:
+		b, err := strconv.ParseBool(raw)
+		if err != nil {
+			return fmt.Errorf("invalid boolean %q", raw)
+		}
+		value.SetBool(b)
+	case reflect.Slice:
+		if value.Type().Elem().Kind() != reflect.String {
+			return fmt.Errorf("unsupported config type %s", value.Type())
+		}
+		value.Set(reflect.ValueOf(splitList(raw)))
+	default:
+		return fmt.Errorf("unsupported config type %s", value.Type())
+	}
+
+	return nil
+}
+
+// splitList memisah daftar dipisah koma, item kosong dibuang.
+func splitList(raw string) []string {
+	var items []string
+	for _, item := range strings.Split(raw, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+
+	return items
+}
+
+func fieldName(prefix string, field reflect.StructField) string {
+	name := strings.Split(field.Tag.Get("yaml"), ",")[0]
+	if name == "" {
+		name = strings.ToLower(field.Name)
+	}
+	if prefix == "" {
+		return name
+	}
+
+	return prefix + "." + name
+}
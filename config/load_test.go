@@ -0,0 +1,267 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+)
+
+// clearConfigEnv mengosongkan semua variabel bertag env (beserta <ENV>_FILE & CONFIG_FILE) supaya
+// environment mesin yang menjalankan test tidak ikut terbaca; nilai kosong dianggap tidak diisi.
+func clearConfigEnv(t *testing.T) {
+	t.Helper()
+
+	t.Setenv(ConfigFileEnv, "")
+
+	var walk func(typ reflect.Type)
+	walk = func(typ reflect.Type) {
+		for i := 0; i < typ.NumField(); i++ {
+			field := typ.Field(i)
+			if field.Type.Kind() == reflect.Struct && field.Type != durationType {
+				walk(field.Type)
+				continue
+			}
+			if key := field.Tag.Get("env"); key != "" {
+				t.Setenv(key, "")
+				t.Setenv(key+fileSuffix, "")
+			}
+		}
+	}
+	walk(reflect.TypeOf(Config{}))
+}
+
+func writeTestFile(t *testing.T, name, content string) string {
+	t.Helper()
+
+	path := filepath.Join(t.TempDir(), name)
+	if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+		t.Fatalf("failed to write %s: %v", name, err)
+	}
+
+	return path
+}
+
+func TestLoadPrecedence(t *testing.T) {
+	const yamlConfig = `
+app:
+  port: 9000
+database:
+  host: db.yaml
+  password: from-yaml
+worker:
+  task_timeout: 2m
+`
+
+	tests := []struct {
+		name     string
+		yaml     bool
+		env      map[string]string
+		fileEnv  map[string]string // <ENV>_FILE -> isi file
+		wantPort int
+		wantHost string
+		wantPass string
+		wantTTL  time.Duration
+	}{
+		{
+			name:     "defaults",
+			wantPort: 8000,
+			wantTTL:  Default().Worker.TaskTimeout,
+		},
+		{
+			name:     "yaml overrides defaults",
+			yaml:     true,
+			wantPort: 9000,
+			wantHost: "db.yaml",
+			wantPass: "from-yaml",
+			wantTTL:  2 * time.Minute,
+		},
+		{
+			name:     "env overrides yaml",
+			yaml:     true,
+			env:      map[string]string{"PORT": "9100", "DB_HOST": "db.env", "WORKER_TASK_TIMEOUT": "90s"},
+			wantPort: 9100,
+			wantHost: "db.env",
+			wantPass: "from-yaml",
+			wantTTL:  90 * time.Second,
+		},
+		{
+			name:     "file env overrides yaml",
+			yaml:     true,
+			env:      map[string]string{"PORT": "9100"},
+			fileEnv:  map[string]string{"DB_PASS": "from-file"},
+			wantPort: 9100,
+			wantHost: "db.yaml",
+			wantPass: "from-file",
+			wantTTL:  2 * time.Minute,
+		},
+		{
+			// env kosong dari template .env tidak menimpa nilai yaml
+			name:     "empty env keeps yaml",
+			yaml:     true,
+			env:      map[string]string{"PORT": "", "DB_HOST": "  "},
+			wantPort: 9000,
+			wantHost: "db.yaml",
+			wantPass: "from-yaml",
+			wantTTL:  2 * time.Minute,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			clearConfigEnv(t)
+
+			var path string
+			if tt.yaml {
+				path = writeTestFile(t, "config.yaml", yamlConfig)
+			}
+			for key, value := range tt.env {
+				t.Setenv(key, value)
+			}
+			for key, content := range tt.fileEnv {
+				t.Setenv(key+fileSuffix, writeTestFile(t, strings.ToLower(key), content))
+			}
+
+			cfg, err := Load(path)
+			if err != nil {
+				t.Fatalf("Load() error = %v", err)
+			}
+
+			if cfg.App.Port != tt.wantPort || cfg.Database.Host != tt.wantHost || cfg.Database.Password != tt.wantPass || cfg.Worker.TaskTimeout != tt.wantTTL {
+				t.Errorf("port/host/pass/timeout = %d/%q/%q/%s, want %d/%q/%q/%s",
+					cfg.App.Port, cfg.Database.Host, cfg.Database.Password, cfg.Worker.TaskTimeout,
+					tt.wantPort, tt.wantHost, tt.wantPass, tt.wantTTL)
+			}
+		})
+	}
+}
+
+func TestLoadConfigFileFromEnv(t *testing.T) {
+	clearConfigEnv(t)
+	t.Setenv(ConfigFileEnv, writeTestFile(t, "config.yaml", "app:\n  port: 9300\n"))
+
+	cfg, err := Load("")
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	if cfg.App.Port != 9300 {
+		t.Errorf("port = %d, want 9300 from %s", cfg.App.Port, ConfigFileEnv)
+	}
+}
+
+func TestLoadFileEnvTrimsTrailingNewline(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		want    string
+	}{
+		{name: "no newline", content: "s3cret", want: "s3cret"},
+		{name: "lf", content: "s3cret\n", want: "s3cret"},
+		{name: "crlf", content: "s3cret\r\n", want: "s3cret"},
+		{name: "several newlines", content: "s3cret\n\n", want: "s3cret"},
+		// hanya newline yang dibuang, spasi bisa jadi bagian dari secret
+		{name: "trailing space kept", content: "s3cret \n", want: "s3cret "},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			clearConfigEnv(t)
+			t.Setenv("DB_PASS_FILE", writeTestFile(t, "db_pass", tt.content))
+
+			cfg, err := Load("")
+			if err != nil {
+				t.Fatalf("Load() error = %v", err)
+			}
+			if cfg.Database.Password != tt.want {
+				t.Errorf("password = %q, want %q", cfg.Database.Password, tt.want)
+			}
+		})
+	}
+}
+
+func TestLoadErrors(t *testing.T) {
+	tests := []struct {
+		name    string
+		yaml    string
+		env     map[string]string
+		setFile string // <ENV> yang juga diisi lewat <ENV>_FILE
+		wantErr string
+	}{
+		{
+			name:    "value and file together",
+			env:     map[string]string{"DB_PASS": "inline"},
+			setFile: "DB_PASS",
+			wantErr: "only one of DB_PASS and DB_PASS_FILE may be set",
+		},
+		{
+			name:    "missing secret file",
+			env:     map[string]string{"DB_PASS_FILE": filepath.Join(os.TempDir(), "does-not-exist", "db_pass")},
+			wantErr: "failed to read DB_PASS_FILE",
+		},
+		{
+			name:    "unknown yaml key",
+			yaml:    "app:\n  prot: 9000\n",
+			wantErr: "field prot not found",
+		},
+		{
+			name:    "unknown yaml section",
+			yaml:    "databse:\n  host: db\n",
+			wantErr: "field databse not found",
+		},
+		{
+			name:    "invalid integer",
+			env:     map[string]string{"PORT": "eighty"},
+			wantErr: `app.port (PORT): invalid integer "eighty"`,
+		},
+		{
+			name:    "invalid duration",
+			env:     map[string]string{"WORKER_TASK_TIMEOUT": "5"},
+			wantErr: `worker.task_timeout (WORKER_TASK_TIMEOUT): invalid duration "5"`,
+		},
+		{
+			name:    "invalid boolean",
+			env:     map[string]string{"JWT_LEGACY_TOKENS": "yes please"},
+			wantErr: `jwt.legacy_tokens (JWT_LEGACY_TOKENS): invalid boolean "yes please"`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			clearConfigEnv(t)
+
+			var path string
+			if tt.yaml != "" {
+				path = writeTestFile(t, "config.yaml", tt.yaml)
+			}
+			for key, value := range tt.env {
+				t.Setenv(key, value)
+			}
+			if tt.setFile != "" {
+				t.Setenv(tt.setFile+fileSuffix, writeTestFile(t, "secret", "from-file"))
+			}
+
+			_, err := Load(path)
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Fatalf("Load() error = %v, want containing %q", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestLoadSplitsListEnv(t *testing.T) {
+	clearConfigEnv(t)
+	t.Setenv("CORS_ALLOWED_ORIGINS", " https://app.example.com, ,https://*.example.com,")
+
+	cfg, err := Load("")
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+
+	want := []string{"https://app.example.com", "https://*.example.com"}
+	if !slices.Equal(cfg.CORS.AllowedOrigins, want) {
+		t.Errorf("allowed origins = %q, want %q", cfg.CORS.AllowedOrigins, want)
+	}
+}
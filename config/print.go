@@ -0,0 +1,129 @@
+package config
+
+import (
+	"fmt"
+	"io"
+	"net/url"
+	"reflect"
+	"strconv"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+const redactedValue = "******"
+
+// Print menulis konfigurasi efektif dalam format YAML dengan secret disensor. Nama environment
+// variable ditulis sebagai komentar agar mudah dicari asal nilainya.
+func (c *Config) Print(w io.Writer) error {
+	root := toYAMLNode(reflect.ValueOf(c).Elem())
+
+	encoder := yaml.NewEncoder(w)
+	encoder.SetIndent(2)
+	defer encoder.Close()
+
+	return encoder.Encode(root)
+}
+
+// String mengembalikan konfigurasi tersensor, sehingga aman jika tidak sengaja dicatat di log.
+func (c *Config) String() string {
+	return fmt.Sprintf("%+v", c.redacted())
+}
+
+func (c *Config) redacted() Config {
+	redacted := *c
+	redactStruct(reflect.ValueOf(&redacted).Elem())
+	return redacted
+}
+
+func redactStruct(v reflect.Value) {
+	t := v.Type()
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		value := v.Field(i)
+
+		if field.Type.Kind() == reflect.Struct && field.Type != durationType {
+			redactStruct(value)
+			continue
+		}
+
+		if value.Kind() == reflect.String {
+			value.SetString(redact(field, value.String()))
+		}
+	}
+}
+
+// redact menyensor field bertag secret; secret:"url" hanya menyensor password di dalam URL.
+func redact(field reflect.StructField, value string) string {
+	if value == "" {
+		return value
+	}
+
+	switch field.Tag.Get("secret") {
+	case "true":
+		return redactedValue
+	case "url":
+		u, err := url.Parse(value)
+		if err != nil {
+			return redactedValue
+		}
+		return u.Redacted()
+	}
+
+	return value
+}
+
+func toYAMLNode(v reflect.Value) *yaml.Node {
+	node := &yaml.Node{Kind: yaml.MappingNode}
+
+	t := v.Type()
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		value := v.Field(i)
+
+		key := &yaml.Node{Kind: yaml.ScalarNode, Value: fieldName("", field)}
+		if field.Type.Kind() == reflect.Struct && field.Type != durationType {
+			node.Content = append(node.Content, key, toYAMLNode(value))
+			continue
+		}
+
+		if value.Kind() == reflect.Slice {
+			list := &yaml.Node{Kind: yaml.SequenceNode, Style: yaml.FlowStyle, LineComment: field.Tag.Get("env")}
+			for j := 0; j < value.Len(); j++ {
+				list.Content = append(list.Content, &yaml.Node{Kind: yaml.ScalarNode, Value: scalarValue(field, value.Index(j)), Style: yaml.DoubleQuotedStyle})
+			}
+			node.Content = append(node.Content, key, list)
+			continue
+		}
+
+		scalar := &yaml.Node{Kind: yaml.ScalarNode, Value: scalarValue(field, value)}
+		if env := field.Tag.Get("env"); env != "" {
+			scalar.LineComment = env
+		}
+		if value.Kind() == reflect.String {
+			scalar.Style = yaml.DoubleQuotedStyle
+		}
+		node.Content = append(node.Content, key, scalar)
+	}
+
+	return node
+}
+
+func scalarValue(field reflect.StructField, value reflect.Value) string {
+	if value.Type() == durationType {
+		return time.Duration(value.Int()).String()
+	}
+
+	switch value.Kind() {
+	case reflect.String:
+		return redact(field, value.String())
+	case reflect.Int:
+		return strconv.FormatInt(value.Int(), 10)
+	case reflect.Float64:
+		return strconv.FormatFloat(value.Float(), 'f', -1, 64)
+	case reflect.Bool:
+		return strconv.FormatBool(value.Bool())
+	}
+
+	return fmt.Sprint(value.Interface())
+}
@@ -3,14 +3,57 @@ package constants
 const (
 	ENUM_RUN_PRODUCTION = "production"
 	ENUM_RUN_TESTING    = "testing"
+	ENUM_RUN_LOCALHOST  = "localhost"
 
-	ENUM_PAGINATION_LIMIT = 10
-	ENUM_PAGINATION_PAGE  = 1
+	ENUM_LOG_MODE_DEVELOPMENT = "development"
+	ENUM_LOG_MODE_PRODUCTION  = "production"
+	ENUM_LOG_ENCODING_JSON    = "json"
+	ENUM_LOG_ENCODING_CONSOLE = "console"
+
+	ENUM_TRACING_EXPORTER_NONE   = "none"
+	ENUM_TRACING_EXPORTER_OTLP   = "otlp"
+	ENUM_TRACING_EXPORTER_STDOUT = "stdout"
+	ENUM_TRACING_EXPORTER_FILE   = "file"
+
+	ENUM_TIMEZONE_DEFAULT = "Asia/Jakarta"
+
+	ENUM_PAGINATION_LIMIT     = 10
+	ENUM_PAGINATION_PAGE      = 1
+	ENUM_PAGINATION_MAX_LIMIT = 100
 
 	ENUM_ROLE_STUDENT            = "student"
 	ENUM_ROLE_LECTURER           = "lecturer"
 	ENUM_ROLE_PRIMARY_LECTURER   = "primary_lecturer"
 	ENUM_ROLE_SECONDARY_LECTURER = "secondary_lecturer"
+	ENUM_ROLE_ADMIN              = "admin"
+
+	ENUM_TOKEN_TYPE_ACCESS  = "access"
+	ENUM_TOKEN_TYPE_REFRESH = "refresh"
+	ENUM_JWT_ACCESS_TTL     = 900    // detik
+	ENUM_JWT_REFRESH_TTL    = 604800 // detik
+	ENUM_JWT_DEFAULT_SECRET = "Template"
+	ENUM_JWT_DEFAULT_ISSUER = "Template"
+
+	ENUM_JWT_ALG_HS256             = "HS256"
+	ENUM_JWT_ALG_RS256             = "RS256"
+	ENUM_JWT_ALG_EDDSA             = "EdDSA"
+	ENUM_JWKS_CACHE_TTL            = 300  // detik
+	ENUM_JWKS_MIN_REFRESH_INTERVAL = 30   // detik
+	ENUM_JWKS_FETCH_TIMEOUT        = 5000 // milidetik
+
+	ENUM_PERMISSION_SUMMARIES_READ       = "summaries:read"
+	ENUM_PERMISSION_TASKS_READ           = "tasks:read"
+	ENUM_PERMISSION_TASKS_RETRY          = "tasks:retry"
+	ENUM_PERMISSION_SESSIONS_RESUMMARIZE = "sessions:resummarize"
+	ENUM_PERMISSION_CONSUMER_CONTROL     = "consumer:control"
+	ENUM_PERMISSION_LOG_LEVEL_WRITE      = "log_level:write"
+
+	ENUM_API_KEY_PREFIX             = "wsk_"
+	ENUM_API_KEY_BYTES              = 32
+	ENUM_API_KEY_DISPLAY_PREFIX_LEN = 12
+	ENUM_API_KEY_LAST_USED_THROTTLE = 60 // detik
+	ENUM_HEADER_REQUEST_ID          = "X-Request-ID"
+	ENUM_HEADER_API_KEY             = "X-API-Key"
 
 	ENUM_DEGREE_S1 = "s1"
 	ENUM_DEGREE_S2 = "s2"
@@ -24,4 +67,71 @@ const (
 	ENUM_SESSION_STATUS_ONGOING            = "ongoing"
 	ENUM_SESSION_STATUS_PROCESSING_SUMMARY = "processing_summary"
 	ENUM_SESSION_STATUS_FINSIHED           = "finished"
+
+	ENUM_QUEUE_SUMMARY_TASK       = "summary_task"
+	ENUM_QUEUE_SUMMARY_TASK_RETRY = "summary_task.retry"
+	ENUM_QUEUE_SUMMARY_TASK_DLQ   = "summary_task.dlq"
+
+	ENUM_TASK_MAX_RETRY        = 3
+	ENUM_TASK_RETRY_BASE_DELAY = 5000 // milidetik, dikali 2^retry
+	ENUM_HEADER_RETRY_COUNT    = "x-retry-count"
+	ENUM_HEADER_LAST_ERROR     = "x-last-error"
+
+	ENUM_OUTBOX_STATUS_PENDING = "pending"
+	ENUM_OUTBOX_STATUS_SENT    = "sent"
+	ENUM_OUTBOX_STATUS_FAILED  = "failed"
+
+	ENUM_EXCHANGE_WORKER_EVENTS     = "worker.events"
+	ENUM_EVENT_SUMMARY_GENERATED    = "summary.generated"
+	ENUM_OUTBOX_RELAY_BATCH_SIZE    = 50
+	ENUM_OUTBOX_RELAY_POLL_INTERVAL = 1000   // milidetik
+	ENUM_OUTBOX_MAX_ATTEMPTS        = 20     // setelah ini event ditandai failed
+	ENUM_OUTBOX_MAX_BACKOFF         = 300000 // milidetik
+
+	ENUM_OUTBOX_RELAY_RESTART_MIN_BACKOFF = 1000  // milidetik
+	ENUM_OUTBOX_RELAY_RESTART_MAX_BACKOFF = 30000 // milidetik
+
+	ENUM_BACKFILL_MODE_ENQUEUE = "enqueue"
+	ENUM_BACKFILL_MODE_PROCESS = "process"
+	ENUM_BACKFILL_BATCH_SIZE   = 100
+
+	ENUM_HEALTH_STATUS_UP     = "up"
+	ENUM_HEALTH_STATUS_DOWN   = "down"
+	ENUM_HEALTH_CHECK_TIMEOUT = 2000 // milidetik
+
+	ENUM_CONSUMER_STATE_RUNNING = "running"
+	ENUM_CONSUMER_STATE_PAUSED  = "paused"
+	ENUM_CONSUMER_STATE_STOPPED = "stopped"
+
+	ENUM_CONSUMER_RESTART_MIN_BACKOFF = 1000  // milidetik
+	ENUM_CONSUMER_RESTART_MAX_BACKOFF = 30000 // milidetik
+	ENUM_TASK_TIMEOUT                 = 300   // detik, batas satu task termasuk panggilan AI service
+
+	ENUM_TASK_TYPE_SUMMARY = "summary"
+
+	ENUM_TASK_LEDGER_STATUS_QUEUED        = "queued"
+	ENUM_TASK_LEDGER_STATUS_PROCESSING    = "processing"
+	ENUM_TASK_LEDGER_STATUS_SUCCEEDED     = "succeeded"
+	ENUM_TASK_LEDGER_STATUS_RETRYING      = "retrying"
+	ENUM_TASK_LEDGER_STATUS_DEAD_LETTERED = "dead_lettered"
+
+	ENUM_HEADER_TASK_ID = "x-task-id"
+
+	ENUM_METADATA_CORRELATION_ID = "x-correlation-id"
+	ENUM_DB_SLOW_QUERY_THRESHOLD = 200 // milidetik
+
+	ENUM_TASK_STATUS_SUCCEEDED = "succeeded"
+	ENUM_TASK_STATUS_FAILED    = "failed"
+
+	ENUM_DB_OPERATION_SAVE_TASK_RESULT = "save_task_result"
+	ENUM_DB_OPERATION_RELAY_OUTBOX     = "relay_outbox"
+
+	ENUM_ERROR_CLASS_NONE              = "none"
+	ENUM_ERROR_CLASS_INVALID_PAYLOAD   = "invalid_payload"
+	ENUM_ERROR_CLASS_INVALID_TIMESTAMP = "invalid_timestamp"
+	ENUM_ERROR_CLASS_AI_SERVICE        = "ai_service"
+	ENUM_ERROR_CLASS_DATABASE          = "database"
+	ENUM_ERROR_CLASS_TIMEOUT           = "timeout"
+	ENUM_ERROR_CLASS_CANCELED          = "canceled"
+	ENUM_ERROR_CLASS_UNKNOWN           = "unknown"
 )
@@ -1,46 +1,167 @@
 package dto
 
 import (
+	"encoding/json"
 	"errors"
+	"net/http"
 	"time"
 
+	"github.com/Amierza/worker-service/apperror"
 	"github.com/Amierza/worker-service/entity"
+	"github.com/Amierza/worker-service/response"
 	"github.com/google/uuid"
 )
 
 const (
 	// ====================================== Failed ======================================
 	// Token
-	MESSAGE_FAILED_PROSES_REQUEST      = "failed proses request"
-	MESSAGE_FAILED_ACCESS_DENIED       = "failed access denied"
-	MESSAGE_FAILED_TOKEN_NOT_FOUND     = "failed token not found"
-	MESSAGE_FAILED_TOKEN_NOT_VALID     = "failed token not valid"
-	MESSAGE_FAILED_TOKEN_DENIED_ACCESS = "failed token denied access"
+	MESSAGE_FAILED_PROSES_REQUEST      = "message.failed_proses_request"
+	MESSAGE_FAILED_GET_DATA_FROM_QUERY = "message.failed_get_data_from_query"
+	MESSAGE_FAILED_ACCESS_DENIED       = "message.failed_access_denied"
+	MESSAGE_FAILED_TOKEN_NOT_FOUND     = "message.failed_token_not_found"
+	MESSAGE_FAILED_TOKEN_NOT_VALID     = "message.failed_token_not_valid"
+	MESSAGE_FAILED_TOKEN_DENIED_ACCESS = "message.failed_token_denied_access"
+	MESSAGE_FAILED_REFRESH_TOKEN       = "message.failed_refresh_token"
+	MESSAGE_FAILED_LOGOUT              = "message.failed_logout"
+	MESSAGE_FAILED_GET_DATA_FROM_BODY  = "message.failed_get_data_from_body"
 
 	// Consume
-	FAILED_CONSUME_SUMMARY_TASKS = "failed consume summary tasks"
+	FAILED_CONSUME_SUMMARY_TASKS   = "message.failed_consume_summary_tasks"
+	MESSAGE_FAILED_START_CONSUMER  = "message.failed_start_consumer"
+	MESSAGE_FAILED_STOP_CONSUMER   = "message.failed_stop_consumer"
+	MESSAGE_FAILED_PAUSE_CONSUMER  = "message.failed_pause_consumer"
+	MESSAGE_FAILED_RESUME_CONSUMER = "message.failed_resume_consumer"
+
+	// Health
+	MESSAGE_FAILED_LIVENESS  = "message.failed_liveness"
+	MESSAGE_FAILED_READINESS = "message.failed_readiness"
+
+	// Summary
+	MESSAGE_FAILED_GET_SESSION_SUMMARY = "message.failed_get_session_summary"
+	MESSAGE_FAILED_GET_THESIS_SUMMARY  = "message.failed_get_thesis_summary"
+
+	// Task
+	MESSAGE_FAILED_GET_LIST_TASK       = "message.failed_get_list_task"
+	MESSAGE_FAILED_GET_DETAIL_TASK     = "message.failed_get_detail_task"
+	MESSAGE_FAILED_RETRY_TASK          = "message.failed_retry_task"
+	MESSAGE_FAILED_RESUMMARIZE_SESSION = "message.failed_resummarize_session"
+
+	// Log level
+	MESSAGE_FAILED_UPDATE_LOG_LEVEL = "message.failed_update_log_level"
 
 	// ====================================== Success ======================================
+	// Token
+	MESSAGE_SUCCESS_REFRESH_TOKEN = "message.success_refresh_token"
+	MESSAGE_SUCCESS_LOGOUT        = "message.success_logout"
+
 	// Consume
-	SUCCESS_CONSUME_SUMMARY_TASKS = "success consume summary tasks"
+	SUCCESS_CONSUME_SUMMARY_TASKS       = "message.success_consume_summary_tasks"
+	MESSAGE_SUCCESS_START_CONSUMER      = "message.success_start_consumer"
+	MESSAGE_SUCCESS_STOP_CONSUMER       = "message.success_stop_consumer"
+	MESSAGE_SUCCESS_PAUSE_CONSUMER      = "message.success_pause_consumer"
+	MESSAGE_SUCCESS_RESUME_CONSUMER     = "message.success_resume_consumer"
+	MESSAGE_SUCCESS_GET_CONSUMER_STATUS = "message.success_get_consumer_status"
+
+	// Summary
+	MESSAGE_SUCCESS_GET_SESSION_SUMMARY = "message.success_get_session_summary"
+	MESSAGE_SUCCESS_GET_THESIS_SUMMARY  = "message.success_get_thesis_summary"
+
+	// Task
+	MESSAGE_SUCCESS_GET_LIST_TASK       = "message.success_get_list_task"
+	MESSAGE_SUCCESS_GET_DETAIL_TASK     = "message.success_get_detail_task"
+	MESSAGE_SUCCESS_RETRY_TASK          = "message.success_retry_task"
+	MESSAGE_SUCCESS_RESUMMARIZE_SESSION = "message.success_resummarize_session"
+
+	// Log level
+	MESSAGE_SUCCESS_GET_LOG_LEVEL    = "message.success_get_log_level"
+	MESSAGE_SUCCESS_UPDATE_LOG_LEVEL = "message.success_update_log_level"
+
+	// Health
+	MESSAGE_SUCCESS_LIVENESS  = "message.success_liveness"
+	MESSAGE_SUCCESS_READINESS = "message.success_readiness"
+
+	// ====================================== Notification ======================================
+	// placeholder: {thesis_title}
+	NOTIFICATION_TITLE_SUMMARY_READY   = "notification.summary_ready.title"
+	NOTIFICATION_MESSAGE_SUMMARY_READY = "notification.summary_ready.message"
 )
 
 var (
+	// Internal
+	ErrInternalServer = apperror.ErrInternal
+	// Bad Request
+	ErrBadRequest = apperror.New("BAD_REQUEST", http.StatusBadRequest, "invalid request")
 	// Not Found
-	ErrNotFound = errors.New("not found")
+	ErrNotFound = apperror.New("NOT_FOUND", http.StatusNotFound, "not found")
 	// Unauthorized
-	ErrUnauthorized = errors.New("unauthorized")
+	ErrUnauthorized = apperror.New("UNAUTHORIZED", http.StatusUnauthorized, "unauthorized")
+	// Forbidden
+	ErrAccessDenied = apperror.New("ACCESS_DENIED", http.StatusForbidden, "access denied")
 
 	// Token
+	ErrTokenNotFound                 = apperror.New("TOKEN_NOT_FOUND", http.StatusUnauthorized, "token not found")
 	ErrGenerateAccessToken           = errors.New("failed to generate access token")
 	ErrGenerateRefreshToken          = errors.New("failed to generate refresh token")
 	ErrUnexpectedSigningMethod       = errors.New("unexpected signing method")
 	ErrDecryptToken                  = errors.New("failed to decrypt token")
-	ErrTokenInvalid                  = errors.New("token invalid")
-	ErrValidateToken                 = errors.New("failed to validate token")
-	ErrGetUserIDFromToken            = errors.New("failed get user id from token")
+	ErrTokenInvalid                  = apperror.New("TOKEN_INVALID", http.StatusUnauthorized, "token invalid")
+	ErrValidateToken                 = apperror.New("TOKEN_VALIDATION_FAILED", http.StatusBadRequest, "failed to validate token")
+	ErrGetUserIDFromToken            = apperror.New("TOKEN_USER_ID_MISSING", http.StatusBadRequest, "failed get user id from token")
 	ErrGetUserRoleFromToken          = errors.New("failed get user role from token")
 	ErrGenerateAccessAndRefreshToken = errors.New("failed generate access and refresh token")
+	ErrInvalidTokenType              = apperror.New("TOKEN_TYPE_INVALID", http.StatusUnauthorized, "invalid token type")
+	ErrTokenRevoked                  = apperror.New("TOKEN_REVOKED", http.StatusUnauthorized, "token has been revoked")
+	ErrRefreshTokenReused            = apperror.New("REFRESH_TOKEN_REUSED", http.StatusUnauthorized, "refresh token reuse detected")
+	ErrUnknownKeyID                  = apperror.New("TOKEN_KEY_UNKNOWN", http.StatusUnauthorized, "unknown token key id")
+	ErrInvalidJWKS                   = errors.New("invalid JWKS document")
+	ErrTokenSigningUnavailable       = apperror.New("TOKEN_SIGNING_UNAVAILABLE", http.StatusNotImplemented, "token signing is unavailable when verifying with JWKS")
+
+	// API Key
+	ErrAPIKeyInvalid      = apperror.New("API_KEY_INVALID", http.StatusUnauthorized, "api key invalid")
+	ErrAPIKeyExpired      = apperror.New("API_KEY_EXPIRED", http.StatusUnauthorized, "api key expired or revoked")
+	ErrInvalidAPIKeyID    = apperror.New("API_KEY_ID_INVALID", http.StatusBadRequest, "invalid api key id")
+	ErrInvalidAPIKeyScope = apperror.New("API_KEY_SCOPE_INVALID", http.StatusBadRequest, "invalid api key scope")
+	ErrAPIKeyNameRequired = apperror.New("API_KEY_NAME_REQUIRED", http.StatusBadRequest, "api key name is required")
+
+	// Task
+	ErrInvalidTaskPayload = apperror.New("TASK_PAYLOAD_INVALID", http.StatusBadRequest, "invalid task payload")
+	ErrGenerateSummary    = errors.New("failed to generate summary via gRPC")
+	ErrPersistTaskResult  = errors.New("failed to persist task result")
+	ErrInvalidTaskID      = apperror.New("TASK_ID_INVALID", http.StatusBadRequest, "invalid task id")
+	ErrInvalidTaskStatus  = apperror.New("TASK_STATUS_INVALID", http.StatusBadRequest, "invalid task status")
+	ErrTaskNotRetryable   = apperror.New("TASK_NOT_RETRYABLE", http.StatusConflict, "task is still queued or processing")
+	ErrTaskHasNoPayload   = apperror.New("TASK_PAYLOAD_MISSING", http.StatusConflict, "task has no stored payload to retry")
+	ErrPublishTask        = apperror.New("TASK_PUBLISH_FAILED", http.StatusServiceUnavailable, "failed to publish task")
+
+	// Message
+	ErrInvalidMessageTimestamp = apperror.New("MESSAGE_TIMESTAMP_INVALID", http.StatusBadRequest, "invalid message timestamp")
+
+	// Summary
+	ErrSummaryNotFound = apperror.New("SUMMARY_NOT_FOUND", http.StatusNotFound, "summary not found")
+	ErrInvalidThesisID = apperror.New("THESIS_ID_INVALID", http.StatusBadRequest, "invalid thesis id")
+	ErrInvalidUserID   = apperror.New("USER_ID_INVALID", http.StatusBadRequest, "invalid user id")
+
+	// Session
+	ErrInvalidSessionID               = apperror.New("SESSION_ID_INVALID", http.StatusBadRequest, "invalid session id")
+	ErrInvalidSessionStatusTransition = apperror.New("SESSION_STATUS_TRANSITION_INVALID", http.StatusConflict, "invalid session status transition")
+
+	// Consumer
+	ErrConsumerAlreadyRunning = apperror.New("CONSUMER_ALREADY_RUNNING", http.StatusConflict, "consumer is already running")
+	ErrConsumerNotRunning     = apperror.New("CONSUMER_NOT_RUNNING", http.StatusConflict, "consumer is not running")
+	ErrConsumerNotPaused      = apperror.New("CONSUMER_NOT_PAUSED", http.StatusConflict, "consumer is not paused")
+	ErrConsumerStopping       = apperror.New("CONSUMER_STOPPING", http.StatusConflict, "previous consumer is still stopping")
+
+	// Health
+	ErrDependencyNotReady       = apperror.New("DEPENDENCY_NOT_READY", http.StatusServiceUnavailable, "one or more dependencies are not ready")
+	ErrRabbitMQConnectionClosed = apperror.New("RABBITMQ_CONNECTION_CLOSED", http.StatusServiceUnavailable, "rabbitmq connection closed")
+
+	// Backfill
+	ErrInvalidBackfillMode      = apperror.New("BACKFILL_MODE_INVALID", http.StatusBadRequest, "invalid backfill mode")
+	ErrInvalidBackfillRate      = apperror.New("BACKFILL_RATE_INVALID", http.StatusBadRequest, "backfill rate must be greater than zero")
+	ErrBackfillFilterMismatched = apperror.New("BACKFILL_FILTER_MISMATCHED", http.StatusConflict, "backfill filter does not match the saved checkpoint")
+
+	// Log level
+	ErrInvalidLogLevel = apperror.New("LOG_LEVEL_INVALID", http.StatusBadRequest, "invalid log level")
 )
 
 // Master
@@ -68,6 +189,7 @@ type (
 		Name         string               `json:"name"`
 		Email        string               `json:"email"`
 		TotalStudent int                  `json:"total_student"`
+		Role         string               `json:"role,omitempty"`
 		StudyProgram StudyProgramResponse `json:"study_program"`
 	}
 	ThesisSummary struct {
@@ -132,3 +254,194 @@ type (
 		Timestamp       string             `json:"timestamp"`
 	}
 )
+
+// Event
+type (
+	SummaryGeneratedEvent struct {
+		SessionID   uuid.UUID `json:"session_id"`
+		SummaryID   uuid.UUID `json:"summary_id"`
+		Version     int       `json:"version"`
+		GeneratedAt time.Time `json:"generated_at"`
+	}
+)
+
+// Backfill
+type (
+	BackfillFilter struct {
+		FacultyID  *uuid.UUID
+		From       *time.Time
+		To         *time.Time
+		StuckAfter time.Duration
+	}
+
+	BackfillRequest struct {
+		Name   string
+		Mode   string
+		DryRun bool
+		Reset  bool
+		Rate   float64 // session per detik
+		Limit  int     // 0 = tanpa batas
+		Filter BackfillFilter
+	}
+
+	BackfillResult struct {
+		Scanned       int       `json:"scanned"`
+		Succeeded     int       `json:"succeeded"`
+		Failed        int       `json:"failed"`
+		LastSessionID uuid.UUID `json:"last_session_id"`
+	}
+)
+
+// Health
+type (
+	DependencyHealth struct {
+		Name      string  `json:"name"`
+		Status    string  `json:"status"`
+		LatencyMs float64 `json:"latency_ms"`
+		Error     string  `json:"error,omitempty"`
+	}
+
+	HealthResponse struct {
+		Status        string             `json:"status"`
+		UptimeSeconds int64              `json:"uptime_seconds"`
+		ConsumerState string             `json:"consumer_state,omitempty"`
+		Checks        []DependencyHealth `json:"checks,omitempty"`
+	}
+)
+
+// Consumer
+type (
+	ConsumerStats struct {
+		InFlight               int64
+		Processed              uint64
+		Failed                 uint64
+		LastProcessedSessionID *uuid.UUID
+		LastProcessedAt        *time.Time
+		LastError              string
+		LastErrorAt            *time.Time
+	}
+
+	ConsumerStatusResponse struct {
+		State                  string     `json:"state"`
+		StartedAt              *time.Time `json:"started_at,omitempty"`
+		UptimeSeconds          int64      `json:"uptime_seconds"`
+		InFlight               int64      `json:"in_flight"`
+		Processed              uint64     `json:"processed"`
+		Failed                 uint64     `json:"failed"`
+		LastError              string     `json:"last_error,omitempty"`
+		LastErrorAt            *time.Time `json:"last_error_at,omitempty"`
+		LastProcessedSessionID *uuid.UUID `json:"last_processed_session_id,omitempty"`
+		LastProcessedAt        *time.Time `json:"last_processed_at,omitempty"`
+	}
+)
+
+// Task Ledger
+type (
+	TaskResponse struct {
+		ID         uuid.UUID         `json:"id"`
+		TaskType   string            `json:"task_type"`
+		Status     entity.TaskStatus `json:"status"`
+		Attempts   int               `json:"attempts"`
+		LastError  string            `json:"last_error,omitempty"`
+		SessionID  uuid.UUID         `json:"session_id"`
+		ReceivedAt time.Time         `json:"received_at"`
+		StartedAt  *time.Time        `json:"started_at,omitempty"`
+		FinishedAt *time.Time        `json:"finished_at,omitempty"`
+		DurationMs int64             `json:"duration_ms"`
+	}
+
+	TaskDetailResponse struct {
+		TaskResponse
+		Payload *TaskSummary `json:"payload,omitempty"`
+	}
+
+	TaskPaginationRequest struct {
+		response.PaginationRequest
+		Status    string `form:"status"`
+		TaskType  string `form:"task_type"`
+		SessionID string `form:"session_id"`
+	}
+
+	TaskPaginationResponse struct {
+		Data []TaskResponse `json:"data"`
+		response.PaginationResponse
+	}
+
+	TaskPaginationRepositoryResponse struct {
+		Tasks []entity.TaskLedger
+		response.PaginationResponse
+	}
+)
+
+// Summary
+type (
+	SummaryResponse struct {
+		ID        uuid.UUID       `json:"id"`
+		Version   int             `json:"version"`
+		Content   json.RawMessage `json:"content"`
+		CreatedAt time.Time       `json:"created_at"`
+	}
+
+	SessionSummaryResponse struct {
+		SessionID     uuid.UUID            `json:"session_id"`
+		ThesisID      uuid.UUID            `json:"thesis_id"`
+		Status        entity.SessionStatus `json:"status"`
+		StartTime     *time.Time           `json:"start_time,omitempty"`
+		EndTime       *time.Time           `json:"end_time,omitempty"`
+		LatestVersion int                  `json:"latest_version"`
+		TotalVersions int                  `json:"total_versions"`
+		Summary       SummaryResponse      `json:"summary"`
+		History       []SummaryResponse    `json:"history"`
+	}
+
+	ThesisSummariesResponse struct {
+		ThesisID uuid.UUID                `json:"thesis_id"`
+		Title    string                   `json:"title"`
+		Sessions []SessionSummaryResponse `json:"sessions"`
+	}
+)
+
+// Auth
+type (
+	RefreshTokenRequest struct {
+		RefreshToken string `json:"refresh_token" binding:"required"`
+	}
+
+	LogoutRequest struct {
+		RefreshToken string `json:"refresh_token"`
+	}
+
+	TokenResponse struct {
+		AccessToken  string `json:"access_token"`
+		RefreshToken string `json:"refresh_token"`
+	}
+)
+
+// API Key
+type (
+	MintAPIKeyRequest struct {
+		Name      string
+		Scopes    []string
+		ExpiresIn time.Duration // 0 berarti tidak kedaluwarsa
+	}
+
+	// MintAPIKeyResponse berisi key asli yang hanya ditampilkan sekali saat dibuat.
+	MintAPIKeyResponse struct {
+		ID        uuid.UUID  `json:"id"`
+		Name      string     `json:"name"`
+		Key       string     `json:"key"`
+		Scopes    []string   `json:"scopes"`
+		ExpiresAt *time.Time `json:"expires_at,omitempty"`
+	}
+)
+
+// Log level
+type (
+	LogLevelResponse struct {
+		Level string `json:"level"`
+	}
+
+	UpdateLogLevelRequest struct {
+		Level string `json:"level" binding:"required"`
+	}
+)
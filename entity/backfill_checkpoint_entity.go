@@ -0,0 +1,23 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type BackfillCheckpoint struct {
+	Name          string    `gorm:"primaryKey" json:"name"`
+	LastCreatedAt time.Time `json:"last_created_at"`
+	LastSessionID uuid.UUID `gorm:"type:uuid" json:"last_session_id"`
+	Processed     int       `gorm:"not null;default:0" json:"processed"`
+	Failed        int       `gorm:"not null;default:0" json:"failed"`
+
+	// filter saat checkpoint dibuat, checkpoint hanya boleh dilanjutkan dengan filter yang sama
+	FilterFacultyID *uuid.UUID `gorm:"type:uuid" json:"filter_faculty_id,omitempty"`
+	FilterFrom      *time.Time `json:"filter_from,omitempty"`
+	FilterTo        *time.Time `json:"filter_to,omitempty"`
+
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
@@ -7,6 +7,8 @@ type (
 	Degree        string
 	Progress      string
 	SessionStatus string
+	OutboxStatus  string
+	TaskStatus    string
 )
 
 const (
@@ -14,6 +16,7 @@ const (
 	LECTURER           Role = constants.ENUM_ROLE_LECTURER
 	PRIMARY_LECTURER   Role = constants.ENUM_ROLE_PRIMARY_LECTURER
 	SECONDARY_LECTURER Role = constants.ENUM_ROLE_SECONDARY_LECTURER
+	ADMIN              Role = constants.ENUM_ROLE_ADMIN
 
 	S1 Degree = constants.ENUM_DEGREE_S1
 	S2 Degree = constants.ENUM_DEGREE_S2
@@ -27,10 +30,20 @@ const (
 	ONGOING            SessionStatus = constants.ENUM_SESSION_STATUS_ONGOING
 	PROCESSING_SUMMARY SessionStatus = constants.ENUM_SESSION_STATUS_PROCESSING_SUMMARY
 	FINISHED           SessionStatus = constants.ENUM_SESSION_STATUS_FINSIHED
+
+	OUTBOX_PENDING OutboxStatus = constants.ENUM_OUTBOX_STATUS_PENDING
+	OUTBOX_SENT    OutboxStatus = constants.ENUM_OUTBOX_STATUS_SENT
+	OUTBOX_FAILED  OutboxStatus = constants.ENUM_OUTBOX_STATUS_FAILED
+
+	TASK_QUEUED        TaskStatus = constants.ENUM_TASK_LEDGER_STATUS_QUEUED
+	TASK_PROCESSING    TaskStatus = constants.ENUM_TASK_LEDGER_STATUS_PROCESSING
+	TASK_SUCCEEDED     TaskStatus = constants.ENUM_TASK_LEDGER_STATUS_SUCCEEDED
+	TASK_RETRYING      TaskStatus = constants.ENUM_TASK_LEDGER_STATUS_RETRYING
+	TASK_DEAD_LETTERED TaskStatus = constants.ENUM_TASK_LEDGER_STATUS_DEAD_LETTERED
 )
 
 func IsValidRole(r Role) bool {
-	return r == STUDENT || r == PRIMARY_LECTURER || r == SECONDARY_LECTURER
+	return r == STUDENT || r == LECTURER || r == PRIMARY_LECTURER || r == SECONDARY_LECTURER || r == ADMIN
 }
 func IsValidDegree(d Degree) bool {
 	return d == S1 || d == S2 || d == S3
@@ -39,5 +52,9 @@ func IsValidProgress(p Progress) bool {
 	return p == BAB1 || p == BAB2 || p == BAB3
 }
 func IsValidSessionStatus(ss SessionStatus) bool {
-	return ss == WAITING || ss == ONGOING || ss == FINISHED
+	return ss == WAITING || ss == ONGOING || ss == PROCESSING_SUMMARY || ss == FINISHED
+}
+
+func IsValidTaskStatus(ts TaskStatus) bool {
+	return ts == TASK_QUEUED || ts == TASK_PROCESSING || ts == TASK_SUCCEEDED || ts == TASK_RETRYING || ts == TASK_DEAD_LETTERED
 }
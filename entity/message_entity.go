@@ -1,6 +1,8 @@
 package entity
 
 import (
+	"time"
+
 	"github.com/google/uuid"
 	"gorm.io/gorm"
 )
@@ -21,6 +23,10 @@ type Message struct {
 
 	ParentMessageID *uuid.UUID `gorm:"type:uuid;index" json:"parent_message_id,omitempty"`
 
+	SentAt   time.Time  `gorm:"index" json:"sent_at"`
+	IsEdited bool       `gorm:"not null;default:false" json:"is_edited"`
+	EditedAt *time.Time `json:"edited_at,omitempty"`
+
 	TimeStamp
 }
 
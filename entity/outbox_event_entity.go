@@ -0,0 +1,25 @@
+package entity
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type OutboxEvent struct {
+	ID          uuid.UUID       `gorm:"type:uuid;primaryKey" json:"id"`
+	EventType   string          `gorm:"not null;index" json:"event_type"`
+	AggregateID uuid.UUID       `gorm:"type:uuid;index" json:"aggregate_id"`
+	Payload     json.RawMessage `gorm:"type:jsonb;not null" json:"payload"`
+	// Headers berisi trace context (traceparent) saat event dibuat, dikirim sebagai header AMQP saat relay
+	Headers map[string]string `gorm:"type:jsonb;serializer:json" json:"headers,omitempty"`
+
+	Status        OutboxStatus `gorm:"not null;default:pending;index:idx_outbox_events_relay,priority:1" json:"status"`
+	Attempts      int          `gorm:"not null;default:0" json:"attempts"`
+	LastError     string       `json:"last_error,omitempty"`
+	NextAttemptAt time.Time    `gorm:"not null;index:idx_outbox_events_relay,priority:2" json:"next_attempt_at"`
+	SentAt        *time.Time   `json:"sent_at,omitempty"`
+
+	TimeStamp
+}
@@ -0,0 +1,28 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// RefreshToken mencatat setiap refresh token yang diterbitkan. Token dalam satu family
+// berasal dari rotasi login yang sama sehingga bisa dicabut bersamaan saat terdeteksi reuse.
+type RefreshToken struct {
+	ID        uuid.UUID  `gorm:"type:uuid;primaryKey" json:"id"`
+	FamilyID  uuid.UUID  `gorm:"type:uuid;not null;index" json:"family_id"`
+	UserID    string     `gorm:"not null;index" json:"user_id"`
+	ExpiresAt time.Time  `gorm:"not null" json:"expires_at"`
+	UsedAt    *time.Time `json:"used_at,omitempty"`
+	RevokedAt *time.Time `json:"revoked_at,omitempty"`
+
+	TimeStamp
+}
+
+// RevokedToken menyimpan jti token yang dicabut sebelum kedaluwarsa.
+type RevokedToken struct {
+	ID        uuid.UUID `gorm:"type:uuid;primaryKey" json:"id"`
+	ExpiresAt time.Time `gorm:"not null;index" json:"expires_at"`
+
+	TimeStamp
+}
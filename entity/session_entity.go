@@ -13,8 +13,9 @@ type Session struct {
 	EndTime   *time.Time    `json:"end_time"`
 	Status    SessionStatus `gorm:"default:waiting" json:"status"`
 
-	Notes    []Note    `gorm:"foreignKey:SessionID;constraint:OnDelete:CASCADE;" json:"notes"`
-	Messages []Message `gorm:"foreignKey:SessionID;constraint:OnDelete:CASCADE;" json:"messages"`
+	Notes     []Note    `gorm:"foreignKey:SessionID;constraint:OnDelete:CASCADE;" json:"notes"`
+	Messages  []Message `gorm:"foreignKey:SessionID;constraint:OnDelete:CASCADE;" json:"messages"`
+	Summaries []Summary `gorm:"foreignKey:SessionID;constraint:OnDelete:CASCADE;" json:"summaries"`
 
 	ThesisID uuid.UUID `gorm:"type:uuid;index" json:"thesis_id,omitempty"`
 	Thesis   Thesis    `gorm:"foreignKey:ThesisID;references:ID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE;" json:"thesis,omitempty"`
@@ -0,0 +1,18 @@
+package entity
+
+import (
+	"encoding/json"
+
+	"github.com/google/uuid"
+)
+
+type Summary struct {
+	ID      uuid.UUID       `gorm:"type:uuid;primaryKey" json:"id"`
+	Content json.RawMessage `gorm:"type:jsonb;not null" json:"content"`
+	Version int             `gorm:"not null;uniqueIndex:idx_summaries_session_version" json:"version"`
+
+	SessionID uuid.UUID `gorm:"type:uuid;uniqueIndex:idx_summaries_session_version" json:"session_id"`
+	Session   Session   `gorm:"foreignKey:SessionID;references:ID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE;" json:"session,omitempty"`
+
+	TimeStamp
+}
@@ -0,0 +1,28 @@
+package entity
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// TaskLedger mencatat satu baris per task yang diterima worker (retry memakai baris yang sama).
+type TaskLedger struct {
+	ID       uuid.UUID  `gorm:"type:uuid;primaryKey" json:"id"`
+	TaskType string     `gorm:"not null;index" json:"task_type"`
+	Status   TaskStatus `gorm:"not null;index" json:"status"`
+	Attempts int        `gorm:"not null;default:0" json:"attempts"`
+
+	LastError string          `json:"last_error,omitempty"`
+	Payload   json.RawMessage `gorm:"type:jsonb" json:"payload,omitempty"`
+
+	ReceivedAt time.Time  `gorm:"not null;index" json:"received_at"`
+	StartedAt  *time.Time `json:"started_at,omitempty"`
+	FinishedAt *time.Time `json:"finished_at,omitempty"`
+	DurationMs int64      `json:"duration_ms"`
+
+	SessionID uuid.UUID `gorm:"type:uuid;index" json:"session_id"`
+
+	TimeStamp
+}
@@ -11,6 +11,7 @@ type User struct {
 	Identifier string    `gorm:"not null" json:"identifier"`
 	Role       Role      `gorm:"not null" json:"role"`
 	Password   string    `json:"password"`
+	Locale     string    `gorm:"type:varchar(8);not null;default:'id'" json:"locale"`
 
 	Messages      []Message      `gorm:"foreignKey:SenderID;constraint:OnDelete:CASCADE;" json:"messages"`
 	Notifications []Notification `gorm:"foreignKey:UserID;constraint:OnDelete:CASCADE;" json:"notifications"`
@@ -6,8 +6,20 @@ import (
 	"log"
 
 	pb "github.com/Amierza/ai-service/proto"
+	"github.com/Amierza/worker-service/constants"
+	"github.com/Amierza/worker-service/logger"
+	"go.opentelemetry.io/contrib/instrumentation/google.golang.org/grpc/otelgrpc"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/connectivity"
 	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
+)
+
+type (
+	// ISummaryClient dipakai consumer, sehingga AI service bisa diganti fake di test.
+	ISummaryClient interface {
+		GenerateSummary(ctx context.Context, req *pb.SummaryRequest) (*pb.SummaryResponse, error)
+	}
 )
 
 type SummaryClient struct {
@@ -20,6 +32,9 @@ func NewSummaryClient(target string) (*SummaryClient, error) {
 	conn, err := grpc.NewClient(
 		target,
 		grpc.WithTransportCredentials(insecure.NewCredentials()),
+		grpc.WithUnaryInterceptor(correlationIDInterceptor),
+		// span client GenerateSummary + header traceparent ke AI service
+		grpc.WithStatsHandler(otelgrpc.NewClientHandler()),
 	)
 	if err != nil {
 		return nil, fmt.Errorf("failed to connect to gRPC server: %w", err)
@@ -41,3 +56,24 @@ func (c *SummaryClient) Close() {
 func (c *SummaryClient) GenerateSummary(ctx context.Context, req *pb.SummaryRequest) (*pb.SummaryResponse, error) {
 	return c.client.GenerateSummary(ctx, req)
 }
+
+// State mengembalikan status koneksi gRPC saat ini. Koneksi idle dipicu untuk connect
+// agar pengecekan berikutnya mencerminkan kondisi AI service yang sebenarnya.
+func (c *SummaryClient) State() connectivity.State {
+	state := c.conn.GetState()
+	if state == connectivity.Idle {
+		c.conn.Connect()
+	}
+
+	return state
+}
+
+// correlationIDInterceptor meneruskan correlation id dari ctx sebagai metadata gRPC agar log
+// AI service bisa dicocokkan dengan log task di worker.
+func correlationIDInterceptor(ctx context.Context, method string, req, reply any, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+	if correlationID := logger.CorrelationIDFromContext(ctx); correlationID != "" {
+		ctx = metadata.AppendToOutgoingContext(ctx, constants.ENUM_METADATA_CORRELATION_ID, correlationID)
+	}
+
+	return invoker(ctx, method, req, reply, cc, opts...)
+}
@@ -0,0 +1,64 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/Amierza/worker-service/dto"
+	"github.com/Amierza/worker-service/i18n"
+	"github.com/Amierza/worker-service/response"
+	"github.com/Amierza/worker-service/service"
+	"github.com/gin-gonic/gin"
+)
+
+type (
+	IAuthHandler interface {
+		RefreshToken(ctx *gin.Context)
+		Logout(ctx *gin.Context)
+	}
+
+	authHandler struct {
+		authService service.IAuthService
+	}
+)
+
+func NewAuthHandler(authService service.IAuthService) *authHandler {
+	return &authHandler{
+		authService: authService,
+	}
+}
+
+func (ah *authHandler) RefreshToken(ctx *gin.Context) {
+	var payload dto.RefreshTokenRequest
+	if err := ctx.ShouldBindJSON(&payload); err != nil {
+		abortWithError(ctx, dto.MESSAGE_FAILED_GET_DATA_FROM_BODY, dto.ErrBadRequest.Wrap(err))
+		return
+	}
+
+	result, err := ah.authService.RefreshToken(ctx, payload)
+	if err != nil {
+		abortWithError(ctx, dto.MESSAGE_FAILED_REFRESH_TOKEN, err)
+		return
+	}
+
+	res := response.BuildResponseSuccess(i18n.FromContext(ctx), dto.MESSAGE_SUCCESS_REFRESH_TOKEN, result)
+	ctx.JSON(http.StatusOK, res)
+}
+
+func (ah *authHandler) Logout(ctx *gin.Context) {
+	var payload dto.LogoutRequest
+	if ctx.Request.ContentLength > 0 {
+		if err := ctx.ShouldBindJSON(&payload); err != nil {
+			abortWithError(ctx, dto.MESSAGE_FAILED_GET_DATA_FROM_BODY, dto.ErrBadRequest.Wrap(err))
+			return
+		}
+	}
+
+	err := ah.authService.Logout(ctx, ctx.GetString("Authorization"), payload)
+	if err != nil {
+		abortWithError(ctx, dto.MESSAGE_FAILED_LOGOUT, err)
+		return
+	}
+
+	res := response.BuildResponseSuccess(i18n.FromContext(ctx), dto.MESSAGE_SUCCESS_LOGOUT, nil)
+	ctx.JSON(http.StatusOK, res)
+}
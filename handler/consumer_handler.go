@@ -4,6 +4,7 @@ import (
 	"net/http"
 
 	"github.com/Amierza/worker-service/dto"
+	"github.com/Amierza/worker-service/i18n"
 	"github.com/Amierza/worker-service/response"
 	"github.com/Amierza/worker-service/service"
 	"github.com/gin-gonic/gin"
@@ -12,28 +13,70 @@ import (
 type (
 	IConsumerHandler interface {
 		StartConsumer(ctx *gin.Context)
+		StopConsumer(ctx *gin.Context)
+		PauseConsumer(ctx *gin.Context)
+		ResumeConsumer(ctx *gin.Context)
+		GetConsumerStatus(ctx *gin.Context)
 	}
 
 	consumerHandler struct {
-		consumerService service.IConsumerService
+		consumerControllerService service.IConsumerControllerService
 	}
 )
 
-func NewConsumerHandler(consumerService service.IConsumerService) *consumerHandler {
+func NewConsumerHandler(consumerControllerService service.IConsumerControllerService) *consumerHandler {
 	return &consumerHandler{
-		consumerService: consumerService,
+		consumerControllerService: consumerControllerService,
 	}
 }
 
 func (ch *consumerHandler) StartConsumer(ctx *gin.Context) {
-	err := ch.consumerService.ConsumeSummaryTasks(ctx)
+	err := ch.consumerControllerService.Start(ctx)
 	if err != nil {
-		status := mapErrorToStatus(err)
-		res := response.BuildResponseFailed(dto.FAILED_CONSUME_SUMMARY_TASKS, err.Error(), nil)
-		ctx.AbortWithStatusJSON(status, res)
+		abortWithError(ctx, dto.MESSAGE_FAILED_START_CONSUMER, err)
 		return
 	}
 
-	res := response.BuildResponseSuccess(dto.SUCCESS_CONSUME_SUMMARY_TASKS, nil)
+	res := response.BuildResponseSuccess(i18n.FromContext(ctx), dto.MESSAGE_SUCCESS_START_CONSUMER, ch.consumerControllerService.Status(ctx))
+	ctx.JSON(http.StatusOK, res)
+}
+
+func (ch *consumerHandler) StopConsumer(ctx *gin.Context) {
+	err := ch.consumerControllerService.Stop(ctx)
+	if err != nil {
+		abortWithError(ctx, dto.MESSAGE_FAILED_STOP_CONSUMER, err)
+		return
+	}
+
+	res := response.BuildResponseSuccess(i18n.FromContext(ctx), dto.MESSAGE_SUCCESS_STOP_CONSUMER, ch.consumerControllerService.Status(ctx))
+	ctx.JSON(http.StatusOK, res)
+}
+
+func (ch *consumerHandler) PauseConsumer(ctx *gin.Context) {
+	err := ch.consumerControllerService.Pause(ctx)
+	if err != nil {
+		abortWithError(ctx, dto.MESSAGE_FAILED_PAUSE_CONSUMER, err)
+		return
+	}
+
+	res := response.BuildResponseSuccess(i18n.FromContext(ctx), dto.MESSAGE_SUCCESS_PAUSE_CONSUMER, ch.consumerControllerService.Status(ctx))
+	ctx.JSON(http.StatusOK, res)
+}
+
+func (ch *consumerHandler) ResumeConsumer(ctx *gin.Context) {
+	err := ch.consumerControllerService.Resume(ctx)
+	if err != nil {
+		abortWithError(ctx, dto.MESSAGE_FAILED_RESUME_CONSUMER, err)
+		return
+	}
+
+	res := response.BuildResponseSuccess(i18n.FromContext(ctx), dto.MESSAGE_SUCCESS_RESUME_CONSUMER, ch.consumerControllerService.Status(ctx))
+	ctx.JSON(http.StatusOK, res)
+}
+
+func (ch *consumerHandler) GetConsumerStatus(ctx *gin.Context) {
+	result := ch.consumerControllerService.Status(ctx)
+
+	res := response.BuildResponseSuccess(i18n.FromContext(ctx), dto.MESSAGE_SUCCESS_GET_CONSUMER_STATUS, result)
 	ctx.JSON(http.StatusOK, res)
 }
@@ -1,23 +1,16 @@
 package handler
 
 import (
-	"net/http"
-
-	"github.com/Amierza/worker-service/dto"
+	"github.com/Amierza/worker-service/i18n"
+	"github.com/Amierza/worker-service/response"
+	"github.com/gin-gonic/gin"
 )
 
-func mapErrorToStatus(err error) int {
-	switch err {
-	case
-		// invalid input
-		dto.ErrValidateToken,
-		dto.ErrGetUserIDFromToken:
-		return http.StatusBadRequest
-	case dto.ErrNotFound:
-		return http.StatusNotFound
-	case dto.ErrUnauthorized:
-		return http.StatusUnauthorized
-	default:
-		return http.StatusInternalServerError
-	}
+// abortWithError mengirim response gagal sesuai status & kode AppError, error aslinya
+// dicatat di ctx.Errors agar ikut tertulis di access log.
+func abortWithError(ctx *gin.Context, message string, err error) {
+	_ = ctx.Error(err)
+
+	status, res := response.BuildResponseError(i18n.FromContext(ctx), message, err)
+	ctx.AbortWithStatusJSON(status, res)
 }
@@ -0,0 +1,54 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/Amierza/worker-service/dto"
+	"github.com/Amierza/worker-service/i18n"
+	"github.com/Amierza/worker-service/response"
+	"github.com/Amierza/worker-service/service"
+	"github.com/gin-gonic/gin"
+)
+
+type (
+	IHealthHandler interface {
+		Liveness(ctx *gin.Context)
+		Readiness(ctx *gin.Context)
+	}
+
+	healthHandler struct {
+		healthService service.IHealthService
+	}
+)
+
+func NewHealthHandler(healthService service.IHealthService) *healthHandler {
+	return &healthHandler{
+		healthService: healthService,
+	}
+}
+
+func (hh *healthHandler) Liveness(ctx *gin.Context) {
+	result, alive := hh.healthService.Liveness(ctx)
+	if !alive {
+		status, res := response.BuildResponseError(i18n.FromContext(ctx), dto.MESSAGE_FAILED_LIVENESS, dto.ErrRabbitMQConnectionClosed)
+		res.Data = result
+		ctx.AbortWithStatusJSON(status, res)
+		return
+	}
+
+	res := response.BuildResponseSuccess(i18n.FromContext(ctx), dto.MESSAGE_SUCCESS_LIVENESS, result)
+	ctx.JSON(http.StatusOK, res)
+}
+
+func (hh *healthHandler) Readiness(ctx *gin.Context) {
+	result, ready := hh.healthService.Readiness(ctx)
+	if !ready {
+		status, res := response.BuildResponseError(i18n.FromContext(ctx), dto.MESSAGE_FAILED_READINESS, dto.ErrDependencyNotReady)
+		res.Data = result
+		ctx.AbortWithStatusJSON(status, res)
+		return
+	}
+
+	res := response.BuildResponseSuccess(i18n.FromContext(ctx), dto.MESSAGE_SUCCESS_READINESS, result)
+	ctx.JSON(http.StatusOK, res)
+}
@@ -0,0 +1,50 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/Amierza/worker-service/dto"
+	"github.com/Amierza/worker-service/i18n"
+	"github.com/Amierza/worker-service/response"
+	"github.com/Amierza/worker-service/service"
+	"github.com/gin-gonic/gin"
+)
+
+type (
+	ILogLevelHandler interface {
+		GetLogLevel(ctx *gin.Context)
+		UpdateLogLevel(ctx *gin.Context)
+	}
+
+	logLevelHandler struct {
+		logLevelService service.ILogLevelService
+	}
+)
+
+func NewLogLevelHandler(logLevelService service.ILogLevelService) *logLevelHandler {
+	return &logLevelHandler{
+		logLevelService: logLevelService,
+	}
+}
+
+func (lh *logLevelHandler) GetLogLevel(ctx *gin.Context) {
+	res := response.BuildResponseSuccess(i18n.FromContext(ctx), dto.MESSAGE_SUCCESS_GET_LOG_LEVEL, lh.logLevelService.GetLogLevel(ctx))
+	ctx.JSON(http.StatusOK, res)
+}
+
+func (lh *logLevelHandler) UpdateLogLevel(ctx *gin.Context) {
+	var payload dto.UpdateLogLevelRequest
+	if err := ctx.ShouldBindJSON(&payload); err != nil {
+		abortWithError(ctx, dto.MESSAGE_FAILED_GET_DATA_FROM_BODY, dto.ErrBadRequest.Wrap(err))
+		return
+	}
+
+	result, err := lh.logLevelService.SetLogLevel(ctx, payload)
+	if err != nil {
+		abortWithError(ctx, dto.MESSAGE_FAILED_UPDATE_LOG_LEVEL, err)
+		return
+	}
+
+	res := response.BuildResponseSuccess(i18n.FromContext(ctx), dto.MESSAGE_SUCCESS_UPDATE_LOG_LEVEL, result)
+	ctx.JSON(http.StatusOK, res)
+}
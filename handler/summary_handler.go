@@ -0,0 +1,50 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/Amierza/worker-service/dto"
+	"github.com/Amierza/worker-service/i18n"
+	"github.com/Amierza/worker-service/response"
+	"github.com/Amierza/worker-service/service"
+	"github.com/gin-gonic/gin"
+)
+
+type (
+	ISummaryHandler interface {
+		GetSessionSummary(ctx *gin.Context)
+		GetThesisSummaries(ctx *gin.Context)
+	}
+
+	summaryHandler struct {
+		summaryService service.ISummaryService
+	}
+)
+
+func NewSummaryHandler(summaryService service.ISummaryService) *summaryHandler {
+	return &summaryHandler{
+		summaryService: summaryService,
+	}
+}
+
+func (sh *summaryHandler) GetSessionSummary(ctx *gin.Context) {
+	result, err := sh.summaryService.GetSessionSummary(ctx, ctx.Param("id"))
+	if err != nil {
+		abortWithError(ctx, dto.MESSAGE_FAILED_GET_SESSION_SUMMARY, err)
+		return
+	}
+
+	res := response.BuildResponseSuccess(i18n.FromContext(ctx), dto.MESSAGE_SUCCESS_GET_SESSION_SUMMARY, result)
+	ctx.JSON(http.StatusOK, res)
+}
+
+func (sh *summaryHandler) GetThesisSummaries(ctx *gin.Context) {
+	result, err := sh.summaryService.GetThesisSummaries(ctx, ctx.Param("id"))
+	if err != nil {
+		abortWithError(ctx, dto.MESSAGE_FAILED_GET_THESIS_SUMMARY, err)
+		return
+	}
+
+	res := response.BuildResponseSuccess(i18n.FromContext(ctx), dto.MESSAGE_SUCCESS_GET_THESIS_SUMMARY, result)
+	ctx.JSON(http.StatusOK, res)
+}
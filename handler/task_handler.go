@@ -0,0 +1,86 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/Amierza/worker-service/dto"
+	"github.com/Amierza/worker-service/i18n"
+	"github.com/Amierza/worker-service/response"
+	"github.com/Amierza/worker-service/service"
+	"github.com/gin-gonic/gin"
+)
+
+type (
+	ITaskHandler interface {
+		GetAllTasks(ctx *gin.Context)
+		GetTaskDetail(ctx *gin.Context)
+		RetryTask(ctx *gin.Context)
+		ResummarizeSession(ctx *gin.Context)
+	}
+
+	taskHandler struct {
+		taskService service.ITaskService
+	}
+)
+
+func NewTaskHandler(taskService service.ITaskService) *taskHandler {
+	return &taskHandler{
+		taskService: taskService,
+	}
+}
+
+func (th *taskHandler) GetAllTasks(ctx *gin.Context) {
+	var payload dto.TaskPaginationRequest
+	if err := ctx.ShouldBindQuery(&payload); err != nil {
+		abortWithError(ctx, dto.MESSAGE_FAILED_GET_DATA_FROM_QUERY, dto.ErrBadRequest.Wrap(err))
+		return
+	}
+
+	result, err := th.taskService.GetAllTasks(ctx, payload)
+	if err != nil {
+		abortWithError(ctx, dto.MESSAGE_FAILED_GET_LIST_TASK, err)
+		return
+	}
+
+	res := response.Response{
+		Status:   true,
+		Messsage: i18n.T(i18n.FromContext(ctx), dto.MESSAGE_SUCCESS_GET_LIST_TASK),
+		Data:     result.Data,
+		Meta:     result.PaginationResponse,
+	}
+
+	ctx.JSON(http.StatusOK, res)
+}
+
+func (th *taskHandler) GetTaskDetail(ctx *gin.Context) {
+	result, err := th.taskService.GetTaskDetail(ctx, ctx.Param("id"))
+	if err != nil {
+		abortWithError(ctx, dto.MESSAGE_FAILED_GET_DETAIL_TASK, err)
+		return
+	}
+
+	res := response.BuildResponseSuccess(i18n.FromContext(ctx), dto.MESSAGE_SUCCESS_GET_DETAIL_TASK, result)
+	ctx.JSON(http.StatusOK, res)
+}
+
+func (th *taskHandler) RetryTask(ctx *gin.Context) {
+	result, err := th.taskService.RetryTask(ctx, ctx.Param("id"))
+	if err != nil {
+		abortWithError(ctx, dto.MESSAGE_FAILED_RETRY_TASK, err)
+		return
+	}
+
+	res := response.BuildResponseSuccess(i18n.FromContext(ctx), dto.MESSAGE_SUCCESS_RETRY_TASK, result)
+	ctx.JSON(http.StatusAccepted, res)
+}
+
+func (th *taskHandler) ResummarizeSession(ctx *gin.Context) {
+	result, err := th.taskService.ResummarizeSession(ctx, ctx.Param("id"))
+	if err != nil {
+		abortWithError(ctx, dto.MESSAGE_FAILED_RESUMMARIZE_SESSION, err)
+		return
+	}
+
+	res := response.BuildResponseSuccess(i18n.FromContext(ctx), dto.MESSAGE_SUCCESS_RESUMMARIZE_SESSION, result)
+	ctx.JSON(http.StatusAccepted, res)
+}
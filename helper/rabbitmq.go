@@ -0,0 +1,29 @@
+package helper
+
+import (
+	"context"
+	"errors"
+
+	amqp "github.com/rabbitmq/amqp091-go"
+)
+
+var ErrPublishNacked = errors.New("message nacked by broker")
+
+// PublishWithConfirm mem-publish pesan lalu menunggu ack dari broker.
+// Channel harus sudah dalam confirm mode (ch.Confirm).
+func PublishWithConfirm(ctx context.Context, ch *amqp.Channel, exchange, key string, msg amqp.Publishing) error {
+	confirm, err := ch.PublishWithDeferredConfirmWithContext(ctx, exchange, key, false, false, msg)
+	if err != nil {
+		return err
+	}
+
+	acked, err := confirm.WaitContext(ctx)
+	if err != nil {
+		return err
+	}
+	if !acked {
+		return ErrPublishNacked
+	}
+
+	return nil
+}
@@ -0,0 +1,84 @@
+package helper
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/Amierza/worker-service/constants"
+)
+
+var (
+	// Format yang sudah membawa offset / zona waktu sendiri
+	zonedTimestampLayouts = []string{
+		time.RFC3339Nano,
+		time.RFC3339,
+		"2006-01-02 15:04:05.999999999 -0700 MST", // format time.Time.String()
+		"2006-01-02 15:04:05.999999999 -0700",
+		"2006-01-02T15:04:05.999999999-0700",
+		time.RFC1123Z,
+		time.RFC1123,
+	}
+
+	// Format tanpa zona waktu, diinterpretasikan memakai location yang diberikan
+	naiveTimestampLayouts = []string{
+		"2006-01-02T15:04:05.999999999",
+		"2006-01-02 15:04:05.999999999",
+		"02/01/2006 15:04:05",
+		"02-01-2006 15:04:05",
+	}
+
+	ErrEmptyTimestamp = errors.New("empty timestamp")
+)
+
+// DefaultLocation mengembalikan zona waktu default aplikasi (WIB).
+// Jika tzdata tidak tersedia di host, dipakai fixed zone +07:00.
+func DefaultLocation() *time.Location {
+	loc, err := time.LoadLocation(constants.ENUM_TIMEZONE_DEFAULT)
+	if err != nil {
+		return time.FixedZone("WIB", 7*60*60)
+	}
+
+	return loc
+}
+
+// ParseTimestamp mem-parsing timestamp dari berbagai format yang diterima.
+// Timestamp tanpa zona waktu dianggap berada di loc (default WIB jika nil).
+func ParseTimestamp(value string, loc *time.Location) (time.Time, error) {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return time.Time{}, ErrEmptyTimestamp
+	}
+	if loc == nil {
+		loc = DefaultLocation()
+	}
+
+	// buang pembacaan monotonic clock, contoh: "... +0700 WIB m=+0.000123"
+	if idx := strings.Index(value, " m="); idx != -1 {
+		value = value[:idx]
+	}
+
+	// unix epoch dalam detik atau milidetik
+	if epoch, err := strconv.ParseInt(value, 10, 64); err == nil {
+		if len(value) >= 13 {
+			return time.UnixMilli(epoch).In(loc), nil
+		}
+		return time.Unix(epoch, 0).In(loc), nil
+	}
+
+	for _, layout := range zonedTimestampLayouts {
+		if t, err := time.Parse(layout, value); err == nil {
+			return t, nil
+		}
+	}
+
+	for _, layout := range naiveTimestampLayouts {
+		if t, err := time.ParseInLocation(layout, value, loc); err == nil {
+			return t, nil
+		}
+	}
+
+	return time.Time{}, fmt.Errorf("unsupported timestamp format %q", value)
+}
@@ -0,0 +1,98 @@
+package helper
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestParseTimestamp(t *testing.T) {
+	wib := time.FixedZone("WIB", 7*60*60)
+	utc8 := time.FixedZone("WITA", 8*60*60)
+
+	// 2024-03-05 14:30:15 WIB == 07:30:15 UTC
+	want := time.Date(2024, 3, 5, 14, 30, 15, 0, wib)
+
+	tests := []struct {
+		name       string
+		value      string
+		loc        *time.Location
+		want       time.Time
+		wantOffset int // offset (detik) yang diharapkan pada hasil
+	}{
+		// format dengan zona waktu: offset dari string dipakai, loc diabaikan
+		{name: "RFC3339 UTC", value: "2024-03-05T07:30:15Z", loc: wib, want: want, wantOffset: 0},
+		{name: "RFC3339 offset", value: "2024-03-05T14:30:15+07:00", loc: utc8, want: want, wantOffset: 7 * 3600},
+		{name: "RFC3339Nano", value: "2024-03-05T07:30:15.123456789Z", loc: wib, want: want.Add(123456789), wantOffset: 0},
+		{name: "time.Time String", value: "2024-03-05 14:30:15.5 +0700 WIB", loc: utc8, want: want.Add(500 * time.Millisecond), wantOffset: 7 * 3600},
+		{name: "time.Time String with monotonic clock", value: "2024-03-05 14:30:15 +0700 WIB m=+0.000123", loc: utc8, want: want, wantOffset: 7 * 3600},
+		{name: "space separated offset", value: "2024-03-05 07:30:15 +0000", loc: wib, want: want, wantOffset: 0},
+		{name: "compact offset", value: "2024-03-05T14:30:15-0000", loc: wib, want: want.Add(7 * time.Hour), wantOffset: 0},
+		{name: "RFC1123Z", value: "Tue, 05 Mar 2024 14:30:15 +0700", loc: utc8, want: want, wantOffset: 7 * 3600},
+
+		// format tanpa zona waktu: diinterpretasikan di loc
+		{name: "naive ISO in WIB", value: "2024-03-05T14:30:15", loc: wib, want: want, wantOffset: 7 * 3600},
+		{name: "naive ISO in WITA", value: "2024-03-05T15:30:15", loc: utc8, want: want, wantOffset: 8 * 3600},
+		{name: "naive space separated", value: "2024-03-05 14:30:15.25", loc: wib, want: want.Add(250 * time.Millisecond), wantOffset: 7 * 3600},
+		{name: "naive day first slash", value: "05/03/2024 14:30:15", loc: wib, want: want, wantOffset: 7 * 3600},
+		{name: "naive day first dash", value: "05-03-2024 14:30:15", loc: wib, want: want, wantOffset: 7 * 3600},
+		{name: "surrounding whitespace", value: "  2024-03-05T14:30:15  ", loc: wib, want: want, wantOffset: 7 * 3600},
+
+		// unix epoch: dikonversi ke loc
+		{name: "unix seconds", value: "1709623815", loc: wib, want: want, wantOffset: 7 * 3600},
+		{name: "unix milliseconds", value: "1709623815250", loc: utc8, want: want.Add(250 * time.Millisecond), wantOffset: 8 * 3600},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseTimestamp(tt.value, tt.loc)
+			if err != nil {
+				t.Fatalf("ParseTimestamp(%q) returned error: %v", tt.value, err)
+			}
+			if !got.Equal(tt.want) {
+				t.Errorf("ParseTimestamp(%q) = %s, want %s", tt.value, got, tt.want)
+			}
+			if _, offset := got.Zone(); offset != tt.wantOffset {
+				t.Errorf("ParseTimestamp(%q) offset = %d, want %d", tt.value, offset, tt.wantOffset)
+			}
+		})
+	}
+}
+
+func TestParseTimestampDefaultLocation(t *testing.T) {
+	got, err := ParseTimestamp("2024-03-05T14:30:15", nil)
+	if err != nil {
+		t.Fatalf("ParseTimestamp returned error: %v", err)
+	}
+
+	if _, offset := got.Zone(); offset != 7*3600 {
+		t.Errorf("naive timestamp with nil location has offset %d, want WIB (+07:00)", offset)
+	}
+}
+
+func TestParseTimestampInvalid(t *testing.T) {
+	tests := []struct {
+		name  string
+		value string
+		empty bool
+	}{
+		{name: "empty", value: "", empty: true},
+		{name: "whitespace only", value: "   ", empty: true},
+		{name: "garbage", value: "kemarin sore"},
+		{name: "date only", value: "2024-03-05"},
+		{name: "month first", value: "03/25/2024 14:30:15"},
+		{name: "invalid day", value: "2024-02-30T10:00:00Z"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := ParseTimestamp(tt.value, time.UTC)
+			if err == nil {
+				t.Fatalf("ParseTimestamp(%q) expected error", tt.value)
+			}
+			if got := errors.Is(err, ErrEmptyTimestamp); got != tt.empty {
+				t.Errorf("errors.Is(err, ErrEmptyTimestamp) = %v, want %v (err: %v)", got, tt.empty, err)
+			}
+		})
+	}
+}
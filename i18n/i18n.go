@@ -0,0 +1,160 @@
+package i18n
+
+import (
+	"embed"
+	"encoding/json"
+	"fmt"
+	"path"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"golang.org/x/text/language"
+)
+
+const (
+	LocaleID = "id"
+	LocaleEN = "en"
+
+	// DefaultLocale dipakai jika Accept-Language & profil user tidak menentukan bahasa
+	DefaultLocale = LocaleID
+
+	// fallbackLocale dipakai jika key belum diterjemahkan di locale yang diminta
+	fallbackLocale = LocaleEN
+
+	// ContextKey adalah key gin.Context tempat middleware Locale menyimpan hasil negosiasi
+	ContextKey = "locale"
+)
+
+//go:embed locales/*.json
+var localeFS embed.FS
+
+var (
+	catalogs  = mustLoadCatalogs()
+	supported = []string{LocaleID, LocaleEN}
+	matcher   = language.NewMatcher([]language.Tag{language.Indonesian, language.English})
+)
+
+func mustLoadCatalogs() map[string]map[string]string {
+	entries, err := localeFS.ReadDir("locales")
+	if err != nil {
+		panic(fmt.Sprintf("failed to read i18n locales: %v", err))
+	}
+
+	result := make(map[string]map[string]string, len(entries))
+	for _, entry := range entries {
+		raw, err := localeFS.ReadFile(path.Join("locales", entry.Name()))
+		if err != nil {
+			panic(fmt.Sprintf("failed to read i18n locale %s: %v", entry.Name(), err))
+		}
+
+		var messages map[string]string
+		if err := json.Unmarshal(raw, &messages); err != nil {
+			panic(fmt.Sprintf("failed to parse i18n locale %s: %v", entry.Name(), err))
+		}
+
+		result[strings.TrimSuffix(entry.Name(), path.Ext(entry.Name()))] = messages
+	}
+
+	return result
+}
+
+// Lookup mencari terjemahan key di locale, lalu di locale fallback (en); ok bernilai false
+// jika key tidak ada di keduanya.
+func Lookup(locale, key string) (string, bool) {
+	if message, ok := catalogs[Normalize(locale)][key]; ok {
+		return message, true
+	}
+
+	message, ok := catalogs[fallbackLocale][key]
+	return message, ok
+}
+
+// T menerjemahkan key, jika tidak ditemukan key itu sendiri yang dikembalikan.
+func T(locale, key string) string {
+	if message, ok := Lookup(locale, key); ok {
+		return message
+	}
+
+	return key
+}
+
+// Tf menerjemahkan key lalu mengganti placeholder {nama} dengan nilai di params.
+func Tf(locale, key string, params map[string]any) string {
+	return Format(T(locale, key), params)
+}
+
+// Format mengganti placeholder {nama} pada message dengan nilai di params.
+func Format(message string, params map[string]any) string {
+	if len(params) == 0 {
+		return message
+	}
+
+	pairs := make([]string, 0, len(params)*2)
+	for name, value := range params {
+		pairs = append(pairs, "{"+name+"}", fmt.Sprint(value))
+	}
+
+	return strings.NewReplacer(pairs...).Replace(message)
+}
+
+// Normalize memetakan tag bahasa bebas (mis. "en-US", "id_ID") ke locale yang didukung,
+// string kosong dikembalikan jika tidak ada yang cocok.
+func Normalize(locale string) string {
+	locale = strings.ReplaceAll(strings.TrimSpace(locale), "_", "-")
+	if locale == "" {
+		return ""
+	}
+	if _, ok := catalogs[locale]; ok {
+		return locale
+	}
+
+	tag, err := language.Parse(locale)
+	if err != nil {
+		return ""
+	}
+
+	base, _ := tag.Base()
+	if _, ok := catalogs[base.String()]; ok {
+		return base.String()
+	}
+
+	return ""
+}
+
+// IsSupported melaporkan apakah locale punya katalog terjemahan.
+func IsSupported(locale string) bool {
+	return Normalize(locale) != ""
+}
+
+// FromAcceptLanguage memilih locale terbaik dari header Accept-Language, string kosong jika
+// header kosong atau tidak ada bahasa yang didukung.
+func FromAcceptLanguage(header string) string {
+	if strings.TrimSpace(header) == "" {
+		return ""
+	}
+
+	tags, _, err := language.ParseAcceptLanguage(header)
+	if err != nil || len(tags) == 0 {
+		return ""
+	}
+
+	_, index, confidence := matcher.Match(tags...)
+	if confidence == language.No {
+		return ""
+	}
+
+	return supported[index]
+}
+
+// FromContext mengambil locale request: hasil middleware Locale, lalu Accept-Language,
+// lalu DefaultLocale.
+func FromContext(ctx *gin.Context) string {
+	if locale := ctx.GetString(ContextKey); locale != "" {
+		return locale
+	}
+	if locale := FromAcceptLanguage(ctx.GetHeader("Accept-Language")); locale != "" {
+		return locale
+	}
+
+	return DefaultLocale
+}
@@ -0,0 +1,34 @@
+// Package testdb menyediakan database SQLite in-memory untuk test repository & service.
+package testdb
+
+import (
+	"testing"
+
+	"github.com/glebarez/sqlite"
+	"gorm.io/gorm"
+	gormlogger "gorm.io/gorm/logger"
+)
+
+// New membuka SQLite in-memory dengan satu koneksi (agar semua statement melihat
+// database yang sama) lalu membuat tabel untuk models. Koneksi ditutup saat test selesai.
+func New(t testing.TB, models ...any) *gorm.DB {
+	t.Helper()
+
+	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{Logger: gormlogger.Discard})
+	if err != nil {
+		t.Fatalf("failed to open sqlite: %v", err)
+	}
+
+	sqlDB, err := db.DB()
+	if err != nil {
+		t.Fatalf("failed to get sql.DB: %v", err)
+	}
+	sqlDB.SetMaxOpenConns(1)
+	t.Cleanup(func() { _ = sqlDB.Close() })
+
+	if err := db.AutoMigrate(models...); err != nil {
+		t.Fatalf("failed to migrate test schema: %v", err)
+	}
+
+	return db
+}
@@ -0,0 +1,230 @@
+package jwt
+
+import (
+	"context"
+	"crypto/ed25519"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math/big"
+	"net/http"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/Amierza/worker-service/constants"
+	"github.com/Amierza/worker-service/dto"
+)
+
+type (
+	jsonWebKey struct {
+		Kid string `json:"kid"`
+		Kty string `json:"kty"`
+		Alg string `json:"alg"`
+		Use string `json:"use"`
+		Crv string `json:"crv"`
+		N   string `json:"n"`
+		E   string `json:"e"`
+		X   string `json:"x"`
+	}
+
+	jsonWebKeySet struct {
+		Keys []jsonWebKey `json:"keys"`
+	}
+
+	// verificationKey menyimpan public key beserta algoritma yang wajib dipakai token bertanda kid ini.
+	verificationKey struct {
+		alg string
+		key any
+	}
+
+	// KeySet memuat public key dari JWKS (file atau URL). Sumber URL di-cache selama ttl dan
+	// di-refresh lebih awal jika token membawa kid yang belum dikenal (rotasi key).
+	KeySet struct {
+		file       string
+		url        string
+		ttl        time.Duration
+		minRefresh time.Duration
+		client     *http.Client
+
+		mu        sync.RWMutex
+		keys      map[string]verificationKey
+		fetchedAt time.Time
+	}
+)
+
+func NewKeySetFromFile(path string) (*KeySet, error) {
+	ks := &KeySet{file: path}
+	if err := ks.refresh(context.Background()); err != nil {
+		return nil, err
+	}
+
+	return ks, nil
+}
+
+func NewKeySetFromURL(url string, ttl time.Duration) (*KeySet, error) {
+	ks := &KeySet{
+		url:        url,
+		ttl:        ttl,
+		minRefresh: constants.ENUM_JWKS_MIN_REFRESH_INTERVAL * time.Second,
+		client:     &http.Client{Timeout: constants.ENUM_JWKS_FETCH_TIMEOUT * time.Millisecond},
+	}
+	if err := ks.refresh(context.Background()); err != nil {
+		return nil, err
+	}
+
+	return ks, nil
+}
+
+// Key mengembalikan key untuk kid. Cache yang kedaluwarsa di-refresh; jika refresh gagal,
+// key lama tetap dipakai agar gangguan sesaat pada endpoint JWKS tidak menolak semua request.
+func (ks *KeySet) Key(ctx context.Context, kid string) (verificationKey, error) {
+	key, found, stale, canRefresh := ks.lookup(kid)
+	if found && !stale {
+		return key, nil
+	}
+
+	if ks.url != "" && (stale || canRefresh) {
+		if err := ks.refresh(ctx); err != nil && !found {
+			return verificationKey{}, err
+		}
+		key, found, _, _ = ks.lookup(kid)
+	}
+
+	if !found {
+		return verificationKey{}, fmt.Errorf("%w: %q", dto.ErrUnknownKeyID, kid)
+	}
+
+	return key, nil
+}
+
+func (ks *KeySet) lookup(kid string) (verificationKey, bool, bool, bool) {
+	ks.mu.RLock()
+	defer ks.mu.RUnlock()
+
+	key, found := ks.keys[kid]
+	age := time.Since(ks.fetchedAt)
+	stale := ks.url != "" && age > ks.ttl
+	canRefresh := age > ks.minRefresh
+
+	return key, found, stale, canRefresh
+}
+
+func (ks *KeySet) refresh(ctx context.Context) error {
+	raw, err := ks.load(ctx)
+	if err != nil {
+		return fmt.Errorf("%w: %w", dto.ErrInvalidJWKS, err)
+	}
+
+	keys, err := parseJWKS(raw)
+	if err != nil {
+		return fmt.Errorf("%w: %w", dto.ErrInvalidJWKS, err)
+	}
+
+	ks.mu.Lock()
+	ks.keys = keys
+	ks.fetchedAt = time.Now()
+	ks.mu.Unlock()
+
+	return nil
+}
+
+func (ks *KeySet) load(ctx context.Context) ([]byte, error) {
+	if ks.file != "" {
+		return os.ReadFile(ks.file)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, ks.url, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	res, err := ks.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("unexpected status %d from %s", res.StatusCode, ks.url)
+	}
+
+	return io.ReadAll(io.LimitReader(res.Body, 1<<20))
+}
+
+func parseJWKS(raw []byte) (map[string]verificationKey, error) {
+	var set jsonWebKeySet
+	if err := json.Unmarshal(raw, &set); err != nil {
+		return nil, err
+	}
+
+	keys := make(map[string]verificationKey, len(set.Keys))
+	for _, jwk := range set.Keys {
+		if jwk.Use != "" && jwk.Use != "sig" {
+			continue
+		}
+		if jwk.Kid == "" {
+			return nil, fmt.Errorf("key without kid")
+		}
+
+		key, err := jwk.verificationKey()
+		if err != nil {
+			return nil, fmt.Errorf("kid %q: %w", jwk.Kid, err)
+		}
+		keys[jwk.Kid] = key
+	}
+
+	if len(keys) == 0 {
+		return nil, fmt.Errorf("no signing keys found")
+	}
+
+	return keys, nil
+}
+
+// verificationKey hanya menerima pasangan kty/alg yang didukung: RSA + RS256 dan OKP Ed25519 + EdDSA.
+func (jwk jsonWebKey) verificationKey() (verificationKey, error) {
+	switch jwk.Kty {
+	case "RSA":
+		if jwk.Alg != "" && jwk.Alg != constants.ENUM_JWT_ALG_RS256 {
+			return verificationKey{}, fmt.Errorf("unsupported alg %q for RSA key", jwk.Alg)
+		}
+
+		n, err := base64.RawURLEncoding.DecodeString(jwk.N)
+		if err != nil {
+			return verificationKey{}, fmt.Errorf("invalid modulus: %w", err)
+		}
+		e, err := base64.RawURLEncoding.DecodeString(jwk.E)
+		if err != nil || len(e) == 0 {
+			return verificationKey{}, fmt.Errorf("invalid exponent")
+		}
+
+		return verificationKey{
+			alg: constants.ENUM_JWT_ALG_RS256,
+			key: &rsa.PublicKey{
+				N: new(big.Int).SetBytes(n),
+				E: int(new(big.Int).SetBytes(e).Int64()),
+			},
+		}, nil
+	case "OKP":
+		if jwk.Crv != "Ed25519" {
+			return verificationKey{}, fmt.Errorf("unsupported curve %q", jwk.Crv)
+		}
+		if jwk.Alg != "" && jwk.Alg != constants.ENUM_JWT_ALG_EDDSA {
+			return verificationKey{}, fmt.Errorf("unsupported alg %q for Ed25519 key", jwk.Alg)
+		}
+
+		x, err := base64.RawURLEncoding.DecodeString(jwk.X)
+		if err != nil || len(x) != ed25519.PublicKeySize {
+			return verificationKey{}, fmt.Errorf("invalid Ed25519 public key")
+		}
+
+		return verificationKey{
+			alg: constants.ENUM_JWT_ALG_EDDSA,
+			key: ed25519.PublicKey(x),
+		}, nil
+	default:
+		return verificationKey{}, fmt.Errorf("unsupported kty %q", jwk.Kty)
+	}
+}
@@ -0,0 +1,354 @@
+package jwt
+
+import (
+	"context"
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/Amierza/worker-service/constants"
+	"github.com/Amierza/worker-service/dto"
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/google/uuid"
+)
+
+type testKeys struct {
+	rsa1, rsa2 *rsa.PrivateKey
+	ed         ed25519.PrivateKey
+}
+
+func newTestKeys(t *testing.T) testKeys {
+	t.Helper()
+
+	var keys testKeys
+	var err error
+	if keys.rsa1, err = rsa.GenerateKey(rand.Reader, 2048); err != nil {
+		t.Fatalf("generate rsa key: %v", err)
+	}
+	if keys.rsa2, err = rsa.GenerateKey(rand.Reader, 2048); err != nil {
+		t.Fatalf("generate rsa key: %v", err)
+	}
+	if _, keys.ed, err = ed25519.GenerateKey(rand.Reader); err != nil {
+		t.Fatalf("generate ed25519 key: %v", err)
+	}
+
+	return keys
+}
+
+func rsaJWK(kid string, key *rsa.PublicKey) jsonWebKey {
+	return jsonWebKey{
+		Kid: kid,
+		Kty: "RSA",
+		Alg: constants.ENUM_JWT_ALG_RS256,
+		Use: "sig",
+		N:   base64.RawURLEncoding.EncodeToString(key.N.Bytes()),
+		E:   base64.RawURLEncoding.EncodeToString(big.NewInt(int64(key.E)).Bytes()),
+	}
+}
+
+func edJWK(kid string, key ed25519.PublicKey) jsonWebKey {
+	return jsonWebKey{
+		Kid: kid,
+		Kty: "OKP",
+		Crv: "Ed25519",
+		Alg: constants.ENUM_JWT_ALG_EDDSA,
+		X:   base64.RawURLEncoding.EncodeToString(key),
+	}
+}
+
+// jwksServer melayani JWKS yang bisa diganti (rotasi) atau dibuat gagal, dan menghitung fetch.
+type jwksServer struct {
+	*httptest.Server
+
+	mu      sync.Mutex
+	keys    []jsonWebKey
+	failing bool
+	fetches int
+}
+
+func newJWKSServer(t *testing.T, keys ...jsonWebKey) *jwksServer {
+	t.Helper()
+
+	s := &jwksServer{keys: keys}
+	s.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		s.mu.Lock()
+		defer s.mu.Unlock()
+
+		s.fetches++
+		if s.failing {
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+		_ = json.NewEncoder(w).Encode(jsonWebKeySet{Keys: s.keys})
+	}))
+	t.Cleanup(s.Close)
+
+	return s
+}
+
+func (s *jwksServer) set(failing bool, keys ...jsonWebKey) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.failing = failing
+	s.keys = keys
+	s.fetches = 0
+}
+
+func (s *jwksServer) fetchCount() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.fetches
+}
+
+func TestKeySetKidMissRefresh(t *testing.T) {
+	keys := newTestKeys(t)
+	k1, k2 := rsaJWK("k1", &keys.rsa1.PublicKey), rsaJWK("k2", &keys.rsa2.PublicKey)
+
+	tests := []struct {
+		name        string
+		fetchedAgo  time.Duration
+		failing     bool
+		kid         string
+		wantFetches int
+		wantErr     error
+	}{
+		{name: "known kid fresh cache", kid: "k1", wantFetches: 0},
+		// kid baru dalam min refresh interval tidak memicu fetch (mencegah flood ke endpoint JWKS)
+		{name: "unknown kid throttled", fetchedAgo: time.Second, kid: "k2", wantFetches: 0, wantErr: dto.ErrUnknownKeyID},
+		{name: "unknown kid after min interval", fetchedAgo: time.Minute, kid: "k2", wantFetches: 1},
+		{name: "unknown kid still unknown after refresh", fetchedAgo: time.Minute, kid: "k9", wantFetches: 1, wantErr: dto.ErrUnknownKeyID},
+		{name: "stale cache refreshed", fetchedAgo: 10 * time.Minute, kid: "k1", wantFetches: 1},
+		{name: "stale cache keeps key when endpoint fails", fetchedAgo: 10 * time.Minute, failing: true, kid: "k1", wantFetches: 1},
+		{name: "unknown kid when endpoint fails", fetchedAgo: time.Minute, failing: true, kid: "k2", wantFetches: 1, wantErr: dto.ErrInvalidJWKS},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := newJWKSServer(t, k1)
+			ks, err := NewKeySetFromURL(server.URL, 5*time.Minute)
+			if err != nil {
+				t.Fatalf("NewKeySetFromURL: %v", err)
+			}
+
+			// endpoint sudah merotasi key: k2 ditambahkan
+			server.set(tt.failing, k1, k2)
+			ks.fetchedAt = time.Now().Add(-tt.fetchedAgo)
+
+			key, err := ks.Key(context.Background(), tt.kid)
+			if got := server.fetchCount(); got != tt.wantFetches {
+				t.Errorf("fetches = %d, want %d", got, tt.wantFetches)
+			}
+			if tt.wantErr != nil {
+				if !errors.Is(err, tt.wantErr) {
+					t.Fatalf("error = %v, want %v", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Key: %v", err)
+			}
+			if key.alg != constants.ENUM_JWT_ALG_RS256 {
+				t.Errorf("alg = %s, want RS256", key.alg)
+			}
+		})
+	}
+}
+
+// accessClaims membuat claim access token yang valid untuk issuer default.
+func accessClaims() CustomClaim {
+	now := time.Now()
+	return CustomClaim{
+		UserID:    "user-1",
+		Role:      "lecturer",
+		TokenType: constants.ENUM_TOKEN_TYPE_ACCESS,
+		FamilyID:  uuid.NewString(),
+		RegisteredClaims: jwt.RegisteredClaims{
+			ID:        uuid.NewString(),
+			Issuer:    constants.ENUM_JWT_DEFAULT_ISSUER,
+			IssuedAt:  jwt.NewNumericDate(now),
+			ExpiresAt: jwt.NewNumericDate(now.Add(time.Minute)),
+		},
+	}
+}
+
+func signWithKid(t *testing.T, method jwt.SigningMethod, kid string, key any) string {
+	t.Helper()
+
+	token := jwt.NewWithClaims(method, accessClaims())
+	if kid != "" {
+		token.Header["kid"] = kid
+	}
+	signed, err := token.SignedString(key)
+	if err != nil {
+		t.Fatalf("failed to sign %s token: %v", method.Alg(), err)
+	}
+
+	return signed
+}
+
+func TestValidateAccessTokenUsesRequestContextForJWKS(t *testing.T) {
+	keys := newTestKeys(t)
+	server := newJWKSServer(t, rsaJWK("k1", &keys.rsa1.PublicKey))
+	ks, err := NewKeySetFromURL(server.URL, 5*time.Minute)
+	if err != nil {
+		t.Fatalf("NewKeySetFromURL: %v", err)
+	}
+	j := &JWT{issuer: constants.ENUM_JWT_DEFAULT_ISSUER, store: newMemoryTokenStore(), keySet: ks}
+
+	server.set(false, rsaJWK("k1", &keys.rsa1.PublicKey), rsaJWK("k2", &keys.rsa2.PublicKey))
+	ks.fetchedAt = time.Now().Add(-time.Minute)
+	token := signWithKid(t, jwt.SigningMethodRS256, "k2", keys.rsa2)
+
+	// request yang sudah dibatalkan tidak boleh tetap mem-fetch JWKS
+	cancelled, cancel := context.WithCancel(context.Background())
+	cancel()
+	if _, err := j.ValidateAccessToken(cancelled, token); !errors.Is(err, dto.ErrTokenInvalid) {
+		t.Fatalf("error with cancelled ctx = %v, want ErrTokenInvalid", err)
+	}
+	if got := server.fetchCount(); got != 0 {
+		t.Fatalf("fetches with cancelled ctx = %d, want 0", got)
+	}
+
+	if _, err := j.ValidateAccessToken(context.Background(), token); err != nil {
+		t.Fatalf("ValidateAccessToken: %v", err)
+	}
+	if got := server.fetchCount(); got != 1 {
+		t.Errorf("fetches = %d, want 1", got)
+	}
+}
+
+func TestParseTokenRejectsAlgorithmConfusion(t *testing.T) {
+	keys := newTestKeys(t)
+	edPublic := keys.ed.Public().(ed25519.PublicKey)
+
+	raw, err := json.Marshal(jsonWebKeySet{Keys: []jsonWebKey{rsaJWK("rsa-1", &keys.rsa1.PublicKey), edJWK("ed-1", edPublic)}})
+	if err != nil {
+		t.Fatalf("marshal jwks: %v", err)
+	}
+	path := filepath.Join(t.TempDir(), "jwks.json")
+	if err := os.WriteFile(path, raw, 0o600); err != nil {
+		t.Fatalf("write jwks: %v", err)
+	}
+	ks, err := NewKeySetFromFile(path)
+	if err != nil {
+		t.Fatalf("NewKeySetFromFile: %v", err)
+	}
+
+	rsaPublicDER, err := x509.MarshalPKIXPublicKey(&keys.rsa1.PublicKey)
+	if err != nil {
+		t.Fatalf("marshal rsa public key: %v", err)
+	}
+
+	jwksJWT := &JWT{issuer: constants.ENUM_JWT_DEFAULT_ISSUER, store: newMemoryTokenStore(), keySet: ks}
+	secretJWT := &JWT{issuer: constants.ENUM_JWT_DEFAULT_ISSUER, store: newMemoryTokenStore(), secretKey: testSecret}
+
+	tests := []struct {
+		name    string
+		jwt     *JWT
+		token   string
+		wantErr bool
+	}{
+		{name: "RS256 with rsa kid", jwt: jwksJWT, token: signWithKid(t, jwt.SigningMethodRS256, "rsa-1", keys.rsa1)},
+		{name: "EdDSA with ed kid", jwt: jwksJWT, token: signWithKid(t, jwt.SigningMethodEdDSA, "ed-1", keys.ed)},
+		// HS256 dengan public key sebagai secret: serangan alg confusion klasik
+		{name: "HS256 keyed with rsa public key", jwt: jwksJWT, token: signWithKid(t, jwt.SigningMethodHS256, "rsa-1", rsaPublicDER), wantErr: true},
+		{name: "HS256 keyed with ed public key", jwt: jwksJWT, token: signWithKid(t, jwt.SigningMethodHS256, "ed-1", []byte(edPublic)), wantErr: true},
+		{name: "RS256 with ed kid", jwt: jwksJWT, token: signWithKid(t, jwt.SigningMethodRS256, "ed-1", keys.rsa1), wantErr: true},
+		{name: "EdDSA with rsa kid", jwt: jwksJWT, token: signWithKid(t, jwt.SigningMethodEdDSA, "rsa-1", keys.ed), wantErr: true},
+		{name: "RS256 signed by another key", jwt: jwksJWT, token: signWithKid(t, jwt.SigningMethodRS256, "rsa-1", keys.rsa2), wantErr: true},
+		{name: "missing kid", jwt: jwksJWT, token: signWithKid(t, jwt.SigningMethodRS256, "", keys.rsa1), wantErr: true},
+		{name: "unknown kid", jwt: jwksJWT, token: signWithKid(t, jwt.SigningMethodRS256, "rsa-9", keys.rsa1), wantErr: true},
+		{name: "none alg", jwt: jwksJWT, token: signWithKid(t, jwt.SigningMethodNone, "rsa-1", jwt.UnsafeAllowNoneSignatureType), wantErr: true},
+		{name: "HS256 in secret mode", jwt: secretJWT, token: signWithKid(t, jwt.SigningMethodHS256, "", []byte(testSecret))},
+		{name: "RS256 in secret mode", jwt: secretJWT, token: signWithKid(t, jwt.SigningMethodRS256, "", keys.rsa1), wantErr: true},
+		{name: "EdDSA in secret mode", jwt: secretJWT, token: signWithKid(t, jwt.SigningMethodEdDSA, "", keys.ed), wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			claims, err := tt.jwt.ValidateAccessToken(context.Background(), tt.token)
+			if tt.wantErr {
+				if !errors.Is(err, dto.ErrTokenInvalid) {
+					t.Fatalf("error = %v, want ErrTokenInvalid", err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("ValidateAccessToken: %v", err)
+			}
+			if claims.UserID != "user-1" {
+				t.Errorf("user id = %q, want user-1", claims.UserID)
+			}
+		})
+	}
+}
+
+func TestParseJWKS(t *testing.T) {
+	keys := newTestKeys(t)
+	rsaKey := rsaJWK("rsa-1", &keys.rsa1.PublicKey)
+	edKey := edJWK("ed-1", keys.ed.Public().(ed25519.PublicKey))
+
+	with := func(k jsonWebKey, edit func(k *jsonWebKey)) jsonWebKey {
+		edit(&k)
+		return k
+	}
+
+	tests := []struct {
+		name     string
+		keys     []jsonWebKey
+		wantKids []string
+		wantErr  bool
+	}{
+		{name: "rsa and ed25519", keys: []jsonWebKey{rsaKey, edKey}, wantKids: []string{"rsa-1", "ed-1"}},
+		{name: "alg omitted", keys: []jsonWebKey{with(rsaKey, func(k *jsonWebKey) { k.Alg = "" })}, wantKids: []string{"rsa-1"}},
+		{name: "encryption key skipped", keys: []jsonWebKey{rsaKey, with(edKey, func(k *jsonWebKey) { k.Use = "enc" })}, wantKids: []string{"rsa-1"}},
+		{name: "only encryption keys", keys: []jsonWebKey{with(rsaKey, func(k *jsonWebKey) { k.Use = "enc" })}, wantErr: true},
+		{name: "empty", wantErr: true},
+		{name: "missing kid", keys: []jsonWebKey{with(rsaKey, func(k *jsonWebKey) { k.Kid = "" })}, wantErr: true},
+		{name: "rsa with HS256 alg", keys: []jsonWebKey{with(rsaKey, func(k *jsonWebKey) { k.Alg = constants.ENUM_JWT_ALG_HS256 })}, wantErr: true},
+		{name: "okp with RS256 alg", keys: []jsonWebKey{with(edKey, func(k *jsonWebKey) { k.Alg = constants.ENUM_JWT_ALG_RS256 })}, wantErr: true},
+		{name: "okp with X25519 curve", keys: []jsonWebKey{with(edKey, func(k *jsonWebKey) { k.Crv = "X25519" })}, wantErr: true},
+		{name: "ed25519 wrong size", keys: []jsonWebKey{with(edKey, func(k *jsonWebKey) { k.X = base64.RawURLEncoding.EncodeToString([]byte("pendek")) })}, wantErr: true},
+		{name: "symmetric key", keys: []jsonWebKey{{Kid: "oct-1", Kty: "oct", Alg: constants.ENUM_JWT_ALG_HS256}}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			raw, err := json.Marshal(jsonWebKeySet{Keys: tt.keys})
+			if err != nil {
+				t.Fatalf("marshal jwks: %v", err)
+			}
+
+			got, err := parseJWKS(raw)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("parseJWKS returned %d keys, want error", len(got))
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("parseJWKS: %v", err)
+			}
+			if len(got) != len(tt.wantKids) {
+				t.Fatalf("got %d keys, want %d", len(got), len(tt.wantKids))
+			}
+			for _, kid := range tt.wantKids {
+				if _, ok := got[kid]; !ok {
+					t.Errorf("kid %q missing", kid)
+				}
+			}
+		})
+	}
+}
@@ -1,131 +1,298 @@
 package jwt
 
 import (
+	"context"
+	"errors"
 	"fmt"
-	"os"
 	"time"
 
+	"github.com/Amierza/worker-service/config"
+	"github.com/Amierza/worker-service/constants"
 	"github.com/Amierza/worker-service/dto"
 	"github.com/golang-jwt/jwt/v5"
+	"github.com/google/uuid"
 )
 
 type (
 	IJWT interface {
-		GenerateToken(userID string, role string) (string, string, error)
-		ValidateToken(token string) (*jwt.Token, error)
-		GetUserIDByToken(tokenString string) (string, error)
-		GetUserRoleByToken(tokenString string) (string, error)
+		GenerateToken(ctx context.Context, userID string, role string) (string, string, error)
+		RefreshToken(ctx context.Context, refreshToken string) (string, string, error)
+		RevokeToken(ctx context.Context, tokenString string) error
+		ValidateAccessToken(ctx context.Context, tokenString string) (*CustomClaim, error)
 	}
 
-	jwtCustomClaim struct {
-		UserID string `json:"user_id"`
-		Role   string `json:"role"`
+	// ITokenStore menyimpan refresh token yang diterbitkan dan jti yang sudah dicabut.
+	ITokenStore interface {
+		SaveRefreshToken(ctx context.Context, jti, familyID uuid.UUID, userID string, expiresAt time.Time) error
+		UseRefreshToken(ctx context.Context, jti uuid.UUID) error
+		RevokeToken(ctx context.Context, jti uuid.UUID, expiresAt time.Time) error
+		RevokeTokenFamily(ctx context.Context, familyID uuid.UUID) error
+		IsTokenRevoked(ctx context.Context, jti, familyID uuid.UUID) (bool, error)
+	}
+
+	CustomClaim struct {
+		UserID    string `json:"user_id"`
+		Role      string `json:"role"`
+		TokenType string `json:"token_type"`
+		FamilyID  string `json:"fid"`
 		jwt.RegisteredClaims
 	}
 
 	JWT struct {
-		secretKey string
-		issuer    string
+		secretKey  string
+		issuer     string
+		accessTTL  time.Duration
+		refreshTTL time.Duration
+		store      ITokenStore
+
+		// legacyTokens menerima access token backend utama yang belum membawa token_type/jti/fid
+		// sampai legacyUntil (nol = tanpa batas, hanya lolos validasi config di localhost).
+		legacyTokens bool
+		legacyUntil  time.Time
+
+		// keySet terisi jika token diverifikasi dengan public key dari JWKS (RS256/EdDSA).
+		// Pada mode ini worker hanya memverifikasi dan tidak bisa menerbitkan token.
+		keySet *KeySet
 	}
 )
 
-func NewJWT() *JWT {
-	return &JWT{
-		secretKey: getSecretKey(),
-		issuer:    "Template",
+// NewJWT memakai JWKS jika JWT_JWKS_FILE/JWT_JWKS_URL diisi, selain itu HS256 dengan secret.
+// Secret default hanya lolos validasi config saat localhost.
+func NewJWT(cfg config.JWTConfig, store ITokenStore) (*JWT, error) {
+	keySet, err := newKeySet(cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	legacyUntil, err := cfg.LegacyTokensDeadline()
+	if err != nil {
+		return nil, fmt.Errorf("invalid legacy tokens deadline: %w", err)
 	}
+
+	secretKey := cfg.Secret
+	if keySet == nil && secretKey == "" {
+		secretKey = constants.ENUM_JWT_DEFAULT_SECRET
+	}
+
+	return &JWT{
+		secretKey:    secretKey,
+		issuer:       cfg.Issuer,
+		accessTTL:    cfg.AccessTTL,
+		refreshTTL:   cfg.RefreshTTL,
+		store:        store,
+		legacyTokens: cfg.LegacyTokens,
+		legacyUntil:  legacyUntil,
+		keySet:       keySet,
+	}, nil
 }
 
-func getSecretKey() string {
-	secretKey := os.Getenv("JWT_SECRET")
-	if secretKey == "" {
-		secretKey = "Template"
+// newKeySet memuat JWKS dari file atau URL; nil berarti memakai HS256 secret.
+func newKeySet(cfg config.JWTConfig) (*KeySet, error) {
+	if cfg.JWKSFile != "" {
+		return NewKeySetFromFile(cfg.JWKSFile)
+	}
+
+	if cfg.JWKSURL != "" {
+		return NewKeySetFromURL(cfg.JWKSURL, cfg.JWKSCacheTTL)
 	}
 
-	return secretKey
+	return nil, nil
 }
 
-func (j *JWT) GenerateToken(userID string, role string) (string, string, error) {
-	accessClaims := jwtCustomClaim{
-		userID,
-		role,
-		jwt.RegisteredClaims{
-			ExpiresAt: jwt.NewNumericDate(time.Now().Add(time.Second * 3600 * 24 * 7)),
-			Issuer:    j.issuer,
-			IssuedAt:  jwt.NewNumericDate(time.Now()),
-		},
+// GenerateToken menerbitkan pasangan access & refresh token dengan family baru.
+func (j *JWT) GenerateToken(ctx context.Context, userID string, role string) (string, string, error) {
+	return j.generateTokenPair(ctx, userID, role, uuid.New())
+}
+
+func (j *JWT) generateTokenPair(ctx context.Context, userID string, role string, familyID uuid.UUID) (string, string, error) {
+	if j.keySet != nil {
+		return "", "", dto.ErrTokenSigningUnavailable
 	}
 
+	now := time.Now()
+
+	accessClaims := j.newClaim(userID, role, constants.ENUM_TOKEN_TYPE_ACCESS, familyID, uuid.New(), now, j.accessTTL)
 	accessToken := jwt.NewWithClaims(jwt.SigningMethodHS256, accessClaims)
 	accessTokenString, err := accessToken.SignedString([]byte(j.secretKey))
 	if err != nil {
 		return "", "", dto.ErrGenerateAccessToken
 	}
 
-	refreshClaims := jwtCustomClaim{
-		userID,
-		role,
-		jwt.RegisteredClaims{
-			ExpiresAt: jwt.NewNumericDate(time.Now().Add(time.Second * 3600 * 24 * 7)),
-			Issuer:    j.issuer,
-			IssuedAt:  jwt.NewNumericDate(time.Now()),
-		},
-	}
-
+	refreshID := uuid.New()
+	refreshClaims := j.newClaim(userID, role, constants.ENUM_TOKEN_TYPE_REFRESH, familyID, refreshID, now, j.refreshTTL)
 	refreshToken := jwt.NewWithClaims(jwt.SigningMethodHS256, refreshClaims)
 	refreshTokenString, err := refreshToken.SignedString([]byte(j.secretKey))
 	if err != nil {
 		return "", "", dto.ErrGenerateRefreshToken
 	}
 
+	if err := j.store.SaveRefreshToken(ctx, refreshID, familyID, userID, refreshClaims.ExpiresAt.Time); err != nil {
+		return "", "", fmt.Errorf("%w: %w", dto.ErrGenerateRefreshToken, err)
+	}
+
 	return accessTokenString, refreshTokenString, nil
 }
 
-func (j *JWT) parseToken(t_ *jwt.Token) (any, error) {
-	if _, ok := t_.Method.(*jwt.SigningMethodHMAC); !ok {
-		return nil, dto.ErrUnexpectedSigningMethod
+func (j *JWT) newClaim(userID, role, tokenType string, familyID, jti uuid.UUID, now time.Time, ttl time.Duration) CustomClaim {
+	return CustomClaim{
+		UserID:    userID,
+		Role:      role,
+		TokenType: tokenType,
+		FamilyID:  familyID.String(),
+		RegisteredClaims: jwt.RegisteredClaims{
+			ID:        jti.String(),
+			ExpiresAt: jwt.NewNumericDate(now.Add(ttl)),
+			Issuer:    j.issuer,
+			IssuedAt:  jwt.NewNumericDate(now),
+		},
+	}
+}
+
+// RefreshToken merotasi refresh token: token lama ditandai terpakai dan pasangan baru diterbitkan
+// dalam family yang sama. Jika token yang sudah terpakai dipakai lagi, seluruh family dicabut.
+func (j *JWT) RefreshToken(ctx context.Context, refreshToken string) (string, string, error) {
+	claims, err := j.parseClaims(ctx, refreshToken)
+	if err != nil {
+		return "", "", err
+	}
+
+	if claims.TokenType != constants.ENUM_TOKEN_TYPE_REFRESH {
+		return "", "", dto.ErrInvalidTokenType
+	}
+
+	jti, familyID, err := claims.ids()
+	if err != nil {
+		return "", "", err
+	}
+
+	if err := j.store.UseRefreshToken(ctx, jti); err != nil {
+		if errors.Is(err, dto.ErrRefreshTokenReused) {
+			if err := j.store.RevokeTokenFamily(ctx, familyID); err != nil {
+				return "", "", err
+			}
+		}
+		return "", "", err
 	}
 
-	return []byte(j.secretKey), nil
+	return j.generateTokenPair(ctx, claims.UserID, claims.Role, familyID)
 }
 
-func (j *JWT) ValidateToken(tokenString string) (*jwt.Token, error) {
-	token, err := jwt.Parse(tokenString, j.parseToken)
+// RevokeToken mencabut token hingga kedaluwarsa. Mencabut refresh token juga mencabut family-nya.
+func (j *JWT) RevokeToken(ctx context.Context, tokenString string) error {
+	claims, err := j.parseClaims(ctx, tokenString)
 	if err != nil {
-		return nil, err
+		return err
+	}
+
+	jti, familyID, err := claims.ids()
+	if err != nil {
+		return err
 	}
 
-	return token, err
+	if err := j.store.RevokeToken(ctx, jti, claims.ExpiresAt.Time); err != nil {
+		return err
+	}
+
+	if claims.TokenType == constants.ENUM_TOKEN_TYPE_REFRESH {
+		return j.store.RevokeTokenFamily(ctx, familyID)
+	}
+
+	return nil
 }
 
-func (j *JWT) GetUserIDByToken(tokenString string) (string, error) {
-	token, err := j.ValidateToken(tokenString)
+// ValidateAccessToken menolak refresh token dan token yang sudah dicabut.
+func (j *JWT) ValidateAccessToken(ctx context.Context, tokenString string) (*CustomClaim, error) {
+	claims, err := j.parseClaims(ctx, tokenString)
+	if err != nil {
+		return nil, err
+	}
+
+	// token lama tanpa token_type tidak punya jti/fid, sehingga tidak bisa dicek revocation-nya
+	if claims.TokenType == "" && j.acceptsLegacyTokens() {
+		if claims.UserID == "" {
+			return nil, dto.ErrTokenInvalid
+		}
+		return claims, nil
+	}
+
+	if claims.TokenType != constants.ENUM_TOKEN_TYPE_ACCESS {
+		return nil, dto.ErrInvalidTokenType
+	}
+
+	jti, familyID, err := claims.ids()
 	if err != nil {
-		return "", dto.ErrValidateToken
+		return nil, err
 	}
 
-	claims, ok := token.Claims.(jwt.MapClaims)
-	if !ok || !token.Valid {
-		return "", dto.ErrTokenInvalid
+	revoked, err := j.store.IsTokenRevoked(ctx, jti, familyID)
+	if err != nil {
+		return nil, err
 	}
+	if revoked {
+		return nil, dto.ErrTokenRevoked
+	}
+
+	return claims, nil
+}
+
+func (j *JWT) acceptsLegacyTokens() bool {
+	return j.legacyTokens && (j.legacyUntil.IsZero() || time.Now().Before(j.legacyUntil))
+}
 
-	userID := fmt.Sprintf("%v", claims["user_id"])
+// parseClaims memakai ctx request agar refresh JWKS ikut batal saat request dibatalkan.
+func (j *JWT) parseClaims(ctx context.Context, tokenString string) (*CustomClaim, error) {
+	claims := &CustomClaim{}
+	keyFunc := func(t_ *jwt.Token) (any, error) {
+		return j.parseToken(ctx, t_)
+	}
+	token, err := jwt.ParseWithClaims(tokenString, claims, keyFunc,
+		jwt.WithIssuer(j.issuer),
+		jwt.WithExpirationRequired(),
+	)
+	if err != nil || !token.Valid {
+		return nil, dto.ErrTokenInvalid
+	}
 
-	return userID, nil
+	return claims, nil
 }
 
-func (j *JWT) GetUserRoleByToken(tokenString string) (string, error) {
-	token, err := j.ValidateToken(tokenString)
+func (c *CustomClaim) ids() (uuid.UUID, uuid.UUID, error) {
+	jti, err := uuid.Parse(c.ID)
+	if err != nil {
+		return uuid.Nil, uuid.Nil, dto.ErrTokenInvalid
+	}
+
+	familyID, err := uuid.Parse(c.FamilyID)
 	if err != nil {
-		return "", dto.ErrValidateToken
+		return uuid.Nil, uuid.Nil, dto.ErrTokenInvalid
 	}
 
-	claims, ok := token.Claims.(jwt.MapClaims)
-	if !ok || !token.Valid {
-		return "", dto.ErrTokenInvalid
+	return jti, familyID, nil
+}
+
+// parseToken memilih key berdasarkan kid dan menolak token yang algoritmanya tidak sama
+// dengan algoritma key tersebut (mencegah alg confusion, mis. HS256 dengan public key).
+func (j *JWT) parseToken(ctx context.Context, t_ *jwt.Token) (any, error) {
+	if j.keySet == nil {
+		if t_.Method.Alg() != constants.ENUM_JWT_ALG_HS256 {
+			return nil, dto.ErrUnexpectedSigningMethod
+		}
+		return []byte(j.secretKey), nil
+	}
+
+	kid, _ := t_.Header["kid"].(string)
+	if kid == "" {
+		return nil, dto.ErrUnknownKeyID
+	}
+
+	key, err := j.keySet.Key(ctx, kid)
+	if err != nil {
+		return nil, err
 	}
 
-	role := fmt.Sprintf("%v", claims["role"])
+	if t_.Method.Alg() != key.alg {
+		return nil, dto.ErrUnexpectedSigningMethod
+	}
 
-	return role, nil
+	return key.key, nil
 }
@@ -0,0 +1,211 @@
+package jwt
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/Amierza/worker-service/config"
+	"github.com/Amierza/worker-service/constants"
+	"github.com/Amierza/worker-service/dto"
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/google/uuid"
+)
+
+const testSecret = "rahasia-uji"
+
+// memoryTokenStore menyimpan refresh token & jti yang dicabut di memori.
+type memoryTokenStore struct {
+	used     map[uuid.UUID]bool
+	revoked  map[uuid.UUID]bool
+	families map[uuid.UUID]bool
+}
+
+func newMemoryTokenStore() *memoryTokenStore {
+	return &memoryTokenStore{used: map[uuid.UUID]bool{}, revoked: map[uuid.UUID]bool{}, families: map[uuid.UUID]bool{}}
+}
+
+func (s *memoryTokenStore) SaveRefreshToken(context.Context, uuid.UUID, uuid.UUID, string, time.Time) error {
+	return nil
+}
+
+func (s *memoryTokenStore) UseRefreshToken(_ context.Context, jti uuid.UUID) error {
+	if s.used[jti] {
+		return dto.ErrRefreshTokenReused
+	}
+	s.used[jti] = true
+	return nil
+}
+
+func (s *memoryTokenStore) RevokeToken(_ context.Context, jti uuid.UUID, _ time.Time) error {
+	s.revoked[jti] = true
+	return nil
+}
+
+func (s *memoryTokenStore) RevokeTokenFamily(_ context.Context, familyID uuid.UUID) error {
+	s.families[familyID] = true
+	return nil
+}
+
+func (s *memoryTokenStore) IsTokenRevoked(_ context.Context, jti, familyID uuid.UUID) (bool, error) {
+	return s.revoked[jti] || s.families[familyID], nil
+}
+
+func newTestJWT(t *testing.T, issuer string, legacyTokens bool) *JWT {
+	t.Helper()
+
+	j, err := NewJWT(config.JWTConfig{
+		Secret:       testSecret,
+		Issuer:       issuer,
+		LegacyTokens: legacyTokens,
+		AccessTTL:    time.Minute,
+		RefreshTTL:   time.Hour,
+	}, newMemoryTokenStore())
+	if err != nil {
+		t.Fatalf("NewJWT: %v", err)
+	}
+
+	return j
+}
+
+// signLegacy menandatangani token dengan format backend utama: tanpa token_type, jti, dan fid.
+func signLegacy(t *testing.T, claims jwt.MapClaims) string {
+	t.Helper()
+
+	token, err := jwt.NewWithClaims(jwt.SigningMethodHS256, claims).SignedString([]byte(testSecret))
+	if err != nil {
+		t.Fatalf("failed to sign token: %v", err)
+	}
+
+	return token
+}
+
+func TestValidateAccessTokenLegacyTokens(t *testing.T) {
+	exp := time.Now().Add(time.Hour).Unix()
+	legacy := jwt.MapClaims{"user_id": "user-1", "role": "student", "iss": constants.ENUM_JWT_DEFAULT_ISSUER, "exp": exp}
+
+	tests := []struct {
+		name         string
+		claims       jwt.MapClaims
+		legacyTokens bool
+		wantErr      error
+	}{
+		{name: "legacy accepted", claims: legacy, legacyTokens: true},
+		{name: "legacy rejected when disabled", claims: legacy, legacyTokens: false, wantErr: dto.ErrInvalidTokenType},
+		{name: "legacy without user id", claims: jwt.MapClaims{"role": "student", "iss": constants.ENUM_JWT_DEFAULT_ISSUER, "exp": exp}, legacyTokens: true, wantErr: dto.ErrTokenInvalid},
+		{name: "legacy wrong issuer", claims: jwt.MapClaims{"user_id": "user-1", "iss": "lain", "exp": exp}, legacyTokens: true, wantErr: dto.ErrTokenInvalid},
+		{name: "legacy without exp", claims: jwt.MapClaims{"user_id": "user-1", "iss": constants.ENUM_JWT_DEFAULT_ISSUER}, legacyTokens: true, wantErr: dto.ErrTokenInvalid},
+		// refresh token tetap ditolak walaupun mode legacy aktif
+		{name: "refresh rejected", claims: jwt.MapClaims{"user_id": "user-1", "token_type": constants.ENUM_TOKEN_TYPE_REFRESH, "iss": constants.ENUM_JWT_DEFAULT_ISSUER, "exp": exp}, legacyTokens: true, wantErr: dto.ErrInvalidTokenType},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			j := newTestJWT(t, constants.ENUM_JWT_DEFAULT_ISSUER, tt.legacyTokens)
+
+			claims, err := j.ValidateAccessToken(context.Background(), signLegacy(t, tt.claims))
+			if tt.wantErr != nil {
+				if !errors.Is(err, tt.wantErr) {
+					t.Fatalf("error = %v, want %v", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("ValidateAccessToken: %v", err)
+			}
+			if claims.UserID != "user-1" || claims.Role != "student" {
+				t.Errorf("claims = %+v, want user-1/student", claims)
+			}
+		})
+	}
+}
+
+func TestValidateAccessTokenIssuer(t *testing.T) {
+	issuer := newTestJWT(t, "backend-utama", false)
+
+	access, _, err := issuer.GenerateToken(context.Background(), "user-1", "lecturer")
+	if err != nil {
+		t.Fatalf("GenerateToken: %v", err)
+	}
+
+	tests := []struct {
+		name    string
+		issuer  string
+		wantErr error
+	}{
+		{name: "same issuer", issuer: "backend-utama"},
+		{name: "different issuer", issuer: constants.ENUM_JWT_DEFAULT_ISSUER, wantErr: dto.ErrTokenInvalid},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := newTestJWT(t, tt.issuer, false).ValidateAccessToken(context.Background(), access)
+			if tt.wantErr == nil && err != nil {
+				t.Fatalf("ValidateAccessToken: %v", err)
+			}
+			if tt.wantErr != nil && !errors.Is(err, tt.wantErr) {
+				t.Fatalf("error = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestValidateAccessTokenRevoked(t *testing.T) {
+	ctx := context.Background()
+	j := newTestJWT(t, constants.ENUM_JWT_DEFAULT_ISSUER, true)
+
+	access, _, err := j.GenerateToken(ctx, "user-1", "lecturer")
+	if err != nil {
+		t.Fatalf("GenerateToken: %v", err)
+	}
+	if _, err := j.ValidateAccessToken(ctx, access); err != nil {
+		t.Fatalf("ValidateAccessToken before revoke: %v", err)
+	}
+
+	if err := j.RevokeToken(ctx, access); err != nil {
+		t.Fatalf("RevokeToken: %v", err)
+	}
+	if _, err := j.ValidateAccessToken(ctx, access); !errors.Is(err, dto.ErrTokenRevoked) {
+		t.Fatalf("ValidateAccessToken after revoke error = %v, want ErrTokenRevoked", err)
+	}
+}
+
+// TestValidateAccessTokenLegacyTokensOptIn: refresh token backend utama punya klaim yang sama
+// dengan access token-nya, sehingga token legacy harus ditolak kecuali diaktifkan eksplisit.
+func TestValidateAccessTokenLegacyTokensOptIn(t *testing.T) {
+	legacy := jwt.MapClaims{"user_id": "user-1", "role": "student", "iss": constants.ENUM_JWT_DEFAULT_ISSUER, "exp": time.Now().Add(time.Hour).Unix()}
+	tomorrow := time.Now().UTC().AddDate(0, 0, 1).Format(time.DateOnly)
+	yesterday := time.Now().UTC().AddDate(0, 0, -1).Format(time.DateOnly)
+
+	tests := []struct {
+		name    string
+		modify  func(cfg *config.JWTConfig)
+		wantErr error
+	}{
+		{name: "default config", modify: func(*config.JWTConfig) {}, wantErr: dto.ErrInvalidTokenType},
+		{name: "opt-in before sunset", modify: func(cfg *config.JWTConfig) { cfg.LegacyTokens, cfg.LegacyTokensUntil = true, tomorrow }},
+		{name: "opt-in after sunset", modify: func(cfg *config.JWTConfig) { cfg.LegacyTokens, cfg.LegacyTokensUntil = true, yesterday }, wantErr: dto.ErrInvalidTokenType},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := config.Default().JWT
+			cfg.Secret = testSecret
+			tt.modify(&cfg)
+
+			j, err := NewJWT(cfg, newMemoryTokenStore())
+			if err != nil {
+				t.Fatalf("NewJWT: %v", err)
+			}
+
+			_, err = j.ValidateAccessToken(context.Background(), signLegacy(t, legacy))
+			if tt.wantErr == nil && err != nil {
+				t.Fatalf("ValidateAccessToken: %v", err)
+			}
+			if tt.wantErr != nil && !errors.Is(err, tt.wantErr) {
+				t.Fatalf("error = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,46 @@
+package logger
+
+import (
+	"context"
+
+	"go.uber.org/zap"
+)
+
+type ctxKey int
+
+const (
+	loggerKey ctxKey = iota
+	correlationIDKey
+)
+
+// WithContext menyimpan logger yang sudah berisi field task / request ke ctx, sehingga
+// service & repository di bawahnya mencatat log dengan field yang sama.
+func WithContext(ctx context.Context, l *zap.Logger) context.Context {
+	return context.WithValue(ctx, loggerKey, l)
+}
+
+// FromContext mengembalikan logger dari ctx, atau fallback jika ctx belum membawa logger.
+func FromContext(ctx context.Context, fallback *zap.Logger) *zap.Logger {
+	if ctx != nil {
+		if l, ok := ctx.Value(loggerKey).(*zap.Logger); ok && l != nil {
+			return l
+		}
+	}
+
+	return fallback
+}
+
+// WithCorrelationID menyimpan correlation id yang diteruskan ke AMQP, gRPC dan log statement DB.
+func WithCorrelationID(ctx context.Context, correlationID string) context.Context {
+	return context.WithValue(ctx, correlationIDKey, correlationID)
+}
+
+// CorrelationIDFromContext mengembalikan string kosong jika ctx tidak membawa correlation id.
+func CorrelationIDFromContext(ctx context.Context) string {
+	if ctx == nil {
+		return ""
+	}
+
+	correlationID, _ := ctx.Value(correlationIDKey).(string)
+	return correlationID
+}
@@ -0,0 +1,94 @@
+package logger
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"gorm.io/gorm"
+	gormlogger "gorm.io/gorm/logger"
+	"gorm.io/gorm/utils"
+)
+
+// GormLogger meneruskan log GORM ke zap memakai logger dari ctx, sehingga setiap statement
+// ikut membawa task_id / correlation_id dari task atau request yang menjalankannya.
+// Level diatur oleh AtomicLevel zap, bukan oleh LogMode GORM.
+type GormLogger struct {
+	logger        *zap.Logger
+	slowThreshold time.Duration
+}
+
+func NewGormLogger(logger *zap.Logger, slowThreshold time.Duration) *GormLogger {
+	return &GormLogger{
+		logger:        logger.Named("gorm").WithOptions(zap.WithCaller(false)),
+		slowThreshold: slowThreshold,
+	}
+}
+
+func (gl *GormLogger) LogMode(gormlogger.LogLevel) gormlogger.Interface {
+	return gl
+}
+
+func (gl *GormLogger) Info(ctx context.Context, msg string, args ...any) {
+	gl.from(ctx).Info(fmt.Sprintf(msg, args...))
+}
+
+func (gl *GormLogger) Warn(ctx context.Context, msg string, args ...any) {
+	gl.from(ctx).Warn(fmt.Sprintf(msg, args...))
+}
+
+func (gl *GormLogger) Error(ctx context.Context, msg string, args ...any) {
+	gl.from(ctx).Error(fmt.Sprintf(msg, args...))
+}
+
+// Trace: error (selain record not found) dicatat sebagai error, query lambat sebagai warn,
+// sisanya debug. SQL hanya dibangun jika level tersebut aktif.
+func (gl *GormLogger) Trace(ctx context.Context, begin time.Time, fc func() (string, int64), err error) {
+	l := gl.from(ctx)
+	elapsed := time.Since(begin)
+
+	level := zapcore.DebugLevel
+	msg := "db statement"
+	switch {
+	case err != nil && !errors.Is(err, gorm.ErrRecordNotFound):
+		level, msg = zapcore.ErrorLevel, "db statement failed"
+	case gl.slowThreshold > 0 && elapsed > gl.slowThreshold:
+		level, msg = zapcore.WarnLevel, "slow db statement"
+	}
+
+	if !l.Core().Enabled(level) {
+		return
+	}
+
+	sql, rows := fc()
+	fields := []zap.Field{
+		zap.String("sql", sql),
+		zap.Int64("rows", rows),
+		zap.Duration("elapsed", elapsed),
+		zap.String("source", utils.FileWithLineNum()),
+	}
+	if level == zapcore.ErrorLevel {
+		fields = append(fields, zap.Error(err))
+	}
+
+	l.Log(level, msg, fields...)
+}
+
+// ParamsFilter membuang nilai parameter dari SQL yang dicatat agar password hash, token,
+// dan isi pesan tidak ikut masuk ke log.
+func (gl *GormLogger) ParamsFilter(_ context.Context, sql string, _ ...any) (string, []any) {
+	return sql, nil
+}
+
+// caller zap selalu menunjuk ke callback GORM, lokasi query asli dicatat di field source.
+func (gl *GormLogger) from(ctx context.Context) *zap.Logger {
+	l := FromContext(ctx, nil)
+	if l == nil {
+		return gl.logger
+	}
+
+	return l.Named("gorm").WithOptions(zap.WithCaller(false))
+}
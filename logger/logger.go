@@ -1,27 +1,99 @@
 package logger
 
 import (
+	"github.com/Amierza/worker-service/config"
+	"github.com/Amierza/worker-service/constants"
 	"go.uber.org/zap"
 	"go.uber.org/zap/zapcore"
+	"gopkg.in/natefinch/lumberjack.v2"
 )
 
-func New(isDev bool) (*zap.Logger, error) {
-	var cfg zap.Config
-	if isDev {
-		cfg = zap.NewDevelopmentConfig()
+// Logger membungkus zap.Logger beserta level yang bisa diubah saat runtime lewat endpoint admin.
+type Logger struct {
+	*zap.Logger
+	Level zap.AtomicLevel
+
+	file *lumberjack.Logger
+}
+
+func New(cfg config.LogConfig) (*Logger, error) {
+	level, err := zap.ParseAtomicLevel(cfg.Level)
+	if err != nil {
+		return nil, err
+	}
+
+	var zapCfg zap.Config
+	if cfg.Mode == constants.ENUM_LOG_MODE_DEVELOPMENT {
+		zapCfg = zap.NewDevelopmentConfig()
 	} else {
-		cfg = zap.NewProductionConfig()
-		cfg.EncoderConfig.TimeKey = "timestamp"
-		cfg.EncoderConfig.EncodeTime = zapcore.ISO8601TimeEncoder
-		cfg.EncoderConfig.StacktraceKey = "stacktrace"
+		zapCfg = zap.NewProductionConfig()
+		zapCfg.EncoderConfig = productionEncoderConfig()
+	}
+
+	zapCfg.Level = level
+	zapCfg.Encoding = cfg.Encoding
+	zapCfg.Sampling = nil
+	if cfg.Sampling.Enabled {
+		zapCfg.Sampling = &zap.SamplingConfig{
+			Initial:    cfg.Sampling.Initial,
+			Thereafter: cfg.Sampling.Thereafter,
+		}
 	}
 
 	// Tambahin caller info supaya bisa tahu dari mana log berasal
-	cfg.EncoderConfig.CallerKey = "caller"
-	logger, err := cfg.Build(zap.AddCaller())
+	zapCfg.EncoderConfig.CallerKey = "caller"
+	opts := []zap.Option{zap.AddCaller()}
+
+	var file *lumberjack.Logger
+	if cfg.File.Path != "" {
+		file = &lumberjack.Logger{
+			Filename:   cfg.File.Path,
+			MaxSize:    cfg.File.MaxSizeMB,
+			MaxBackups: cfg.File.MaxBackups,
+			MaxAge:     cfg.File.MaxAgeDays,
+			Compress:   cfg.File.Compress,
+			LocalTime:  true,
+		}
+
+		// file selalu JSON tanpa warna agar mudah diproses log shipper, level mengikuti AtomicLevel
+		fileCore := zapcore.NewCore(zapcore.NewJSONEncoder(productionEncoderConfig()), zapcore.AddSync(file), level)
+		opts = append(opts, zap.WrapCore(func(core zapcore.Core) zapcore.Core {
+			return zapcore.NewTee(core, fileCore)
+		}))
+	}
+
+	logger, err := zapCfg.Build(opts...)
 	if err != nil {
+		if file != nil {
+			_ = file.Close()
+		}
 		return nil, err
 	}
 
-	return logger, nil
+	return &Logger{
+		Logger: logger,
+		Level:  level,
+		file:   file,
+	}, nil
+}
+
+// Close mem-flush buffer lalu menutup file log jika ada.
+func (l *Logger) Close() error {
+	_ = l.Sync() // error sync stderr (mis. EINVAL di terminal) diabaikan
+
+	if l.file != nil {
+		return l.file.Close()
+	}
+
+	return nil
+}
+
+func productionEncoderConfig() zapcore.EncoderConfig {
+	encoderCfg := zap.NewProductionEncoderConfig()
+	encoderCfg.TimeKey = "timestamp"
+	encoderCfg.EncodeTime = zapcore.ISO8601TimeEncoder
+	encoderCfg.StacktraceKey = "stacktrace"
+	encoderCfg.CallerKey = "caller"
+
+	return encoderCfg
 }
@@ -0,0 +1,75 @@
+package metrics
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+const namespace = "worker"
+
+// Label sengaja dibatasi pada nilai yang jumlahnya tetap (task_type, error_class,
+// status, operation) supaya cardinality metric tidak meledak. Jangan pakai id sebagai label.
+var (
+	TasksReceived = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "tasks_received_total",
+		Help:      "Total tasks received from the queue.",
+	}, []string{"task_type"})
+
+	TasksSucceeded = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "tasks_succeeded_total",
+		Help:      "Total tasks processed successfully.",
+	}, []string{"task_type"})
+
+	TasksFailed = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "tasks_failed_total",
+		Help:      "Total task processing attempts that failed.",
+	}, []string{"task_type", "error_class"})
+
+	TasksRetried = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "tasks_retried_total",
+		Help:      "Total tasks scheduled for retry.",
+	}, []string{"task_type", "error_class"})
+
+	TasksDeadLettered = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "tasks_dead_lettered_total",
+		Help:      "Total tasks moved to the dead letter queue.",
+	}, []string{"task_type", "error_class"})
+
+	TaskDuration = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Name:      "task_duration_seconds",
+		Help:      "End-to-end task processing duration.",
+		Buckets:   []float64{0.5, 1, 2.5, 5, 10, 20, 30, 60, 120, 300},
+	}, []string{"task_type", "status"})
+
+	AIRequestDuration = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Name:      "ai_generate_summary_duration_seconds",
+		Help:      "Latency of GenerateSummary gRPC calls to the AI service.",
+		Buckets:   []float64{0.25, 0.5, 1, 2.5, 5, 10, 20, 30, 60, 120},
+	}, []string{"code"})
+
+	DBWriteDuration = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Name:      "db_write_duration_seconds",
+		Help:      "Latency of database write transactions.",
+		Buckets:   prometheus.DefBuckets,
+	}, []string{"operation", "status"})
+
+	TasksInFlight = promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: namespace,
+		Name:      "tasks_in_flight",
+		Help:      "Tasks currently being processed.",
+	}, []string{"task_type"})
+
+	ConsumerRunning = promauto.NewGauge(prometheus.GaugeOpts{
+		Namespace: namespace,
+		Name:      "consumer_running",
+		Help:      "1 if the queue consumer loop is active, 0 otherwise.",
+	})
+)
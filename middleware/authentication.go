@@ -1,12 +1,16 @@
 package middleware
 
 import (
-	"net/http"
+	"errors"
 	"strings"
 
+	"github.com/Amierza/worker-service/apperror"
+	"github.com/Amierza/worker-service/constants"
 	"github.com/Amierza/worker-service/dto"
+	"github.com/Amierza/worker-service/i18n"
 	"github.com/Amierza/worker-service/jwt"
 	"github.com/Amierza/worker-service/response"
+	"github.com/Amierza/worker-service/service"
 	"github.com/gin-gonic/gin"
 )
 
@@ -14,40 +18,62 @@ func Authentication(jwtService jwt.IJWT) gin.HandlerFunc {
 	return func(ctx *gin.Context) {
 		authHeader := ctx.GetHeader("Authorization")
 		if authHeader == "" {
-			res := response.BuildResponseFailed(dto.MESSAGE_FAILED_PROSES_REQUEST, dto.MESSAGE_FAILED_TOKEN_NOT_FOUND, nil)
-			ctx.AbortWithStatusJSON(http.StatusUnauthorized, res)
+			ctx.AbortWithStatusJSON(response.BuildResponseError(i18n.FromContext(ctx), dto.MESSAGE_FAILED_PROSES_REQUEST, dto.ErrTokenNotFound))
 			return
 		}
 
 		if !strings.Contains(authHeader, "Bearer") {
-			res := response.BuildResponseFailed(dto.MESSAGE_FAILED_PROSES_REQUEST, dto.MESSAGE_FAILED_TOKEN_NOT_VALID, nil)
-			ctx.AbortWithStatusJSON(http.StatusUnauthorized, res)
+			ctx.AbortWithStatusJSON(response.BuildResponseError(i18n.FromContext(ctx), dto.MESSAGE_FAILED_PROSES_REQUEST, dto.ErrTokenInvalid))
 			return
 		}
 
 		authHeader = strings.Replace(authHeader, "Bearer ", "", -1)
-		token, err := jwtService.ValidateToken(authHeader)
+		claims, err := jwtService.ValidateAccessToken(ctx, authHeader)
 		if err != nil {
-			res := response.BuildResponseFailed(dto.MESSAGE_FAILED_PROSES_REQUEST, dto.MESSAGE_FAILED_TOKEN_NOT_VALID, nil)
-			ctx.AbortWithStatusJSON(http.StatusUnauthorized, res)
+			// error non-AppError (mis. gagal query revocation) tetap dijawab 401, bukan 500
+			if !errors.As(err, new(*apperror.AppError)) {
+				_ = ctx.Error(err)
+				err = dto.ErrTokenInvalid
+			}
+			ctx.AbortWithStatusJSON(response.BuildResponseError(i18n.FromContext(ctx), dto.MESSAGE_FAILED_PROSES_REQUEST, err))
 			return
 		}
 
-		if !token.Valid {
-			res := response.BuildResponseFailed(dto.MESSAGE_FAILED_PROSES_REQUEST, dto.MESSAGE_FAILED_TOKEN_DENIED_ACCESS, nil)
-			ctx.AbortWithStatusJSON(http.StatusUnauthorized, res)
+		ctx.Set("Authorization", authHeader)
+		ctx.Set("user_id", claims.UserID)
+		ctx.Set("role", claims.Role)
+		ctx.Next()
+	}
+}
+
+// AuthenticationOrAPIKey menerima API key lewat header X-API-Key (atau Bearer berprefix wsk_),
+// selain itu request diteruskan ke Authentication JWT biasa.
+func AuthenticationOrAPIKey(jwtService jwt.IJWT, apiKeyService service.IAPIKeyService) gin.HandlerFunc {
+	jwtAuthentication := Authentication(jwtService)
+
+	return func(ctx *gin.Context) {
+		rawKey := ctx.GetHeader(constants.ENUM_HEADER_API_KEY)
+		if rawKey == "" {
+			bearer := strings.TrimPrefix(ctx.GetHeader("Authorization"), "Bearer ")
+			if strings.HasPrefix(bearer, constants.ENUM_API_KEY_PREFIX) {
+				rawKey = bearer
+			}
+		}
+
+		if rawKey == "" {
+			jwtAuthentication(ctx)
 			return
 		}
 
-		userID, err := jwtService.GetUserIDByToken(authHeader)
+		apiKey, err := apiKeyService.AuthenticateAPIKey(ctx, rawKey)
 		if err != nil {
-			res := response.BuildResponseFailed(dto.MESSAGE_FAILED_PROSES_REQUEST, err.Error(), nil)
-			ctx.AbortWithStatusJSON(http.StatusUnauthorized, res)
+			_ = ctx.Error(err)
+			ctx.AbortWithStatusJSON(response.BuildResponseError(i18n.FromContext(ctx), dto.MESSAGE_FAILED_PROSES_REQUEST, err))
 			return
 		}
 
-		ctx.Set("Authorization", authHeader)
-		ctx.Set("user_id", userID)
+		ctx.Set("api_key_id", apiKey.ID.String())
+		ctx.Set("scopes", apiKey.ScopeList())
 		ctx.Next()
 	}
 }
@@ -0,0 +1,225 @@
+package middleware
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/Amierza/worker-service/config"
+	"github.com/Amierza/worker-service/constants"
+	"github.com/Amierza/worker-service/dto"
+	"github.com/Amierza/worker-service/entity"
+	"github.com/Amierza/worker-service/jwt"
+	"github.com/Amierza/worker-service/repository"
+	"github.com/Amierza/worker-service/response"
+	"github.com/Amierza/worker-service/service"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+// memoryAPIKeyRepo menyimpan API key di memori berdasarkan hash.
+type memoryAPIKeyRepo struct {
+	keys map[string]*entity.APIKey
+}
+
+var _ repository.IAPIKeyRepository = (*memoryAPIKeyRepo)(nil)
+
+func (r *memoryAPIKeyRepo) CreateAPIKey(_ context.Context, _ *gorm.DB, apiKey entity.APIKey) error {
+	r.keys[apiKey.KeyHash] = &apiKey
+	return nil
+}
+
+func (r *memoryAPIKeyRepo) GetAPIKeyByHash(_ context.Context, _ *gorm.DB, keyHash string) (entity.APIKey, error) {
+	apiKey, ok := r.keys[keyHash]
+	if !ok {
+		return entity.APIKey{}, dto.ErrNotFound
+	}
+	return *apiKey, nil
+}
+
+func (r *memoryAPIKeyRepo) TouchAPIKeyLastUsed(_ context.Context, _ *gorm.DB, _ uuid.UUID, _ time.Time, _ time.Duration) error {
+	return nil
+}
+
+func (r *memoryAPIKeyRepo) RevokeAPIKey(_ context.Context, _ *gorm.DB, id uuid.UUID) error {
+	for _, apiKey := range r.keys {
+		if apiKey.ID == id {
+			now := time.Now()
+			apiKey.RevokedAt = &now
+			return nil
+		}
+	}
+	return dto.ErrNotFound
+}
+
+func (r *memoryAPIKeyRepo) byID(id uuid.UUID) *entity.APIKey {
+	for _, apiKey := range r.keys {
+		if apiKey.ID == id {
+			return apiKey
+		}
+	}
+	return nil
+}
+
+// noopTokenStore: tidak ada token yang dicabut.
+type noopTokenStore struct{}
+
+func (noopTokenStore) SaveRefreshToken(context.Context, uuid.UUID, uuid.UUID, string, time.Time) error {
+	return nil
+}
+
+func (noopTokenStore) UseRefreshToken(context.Context, uuid.UUID) error {
+	return nil
+}
+
+func (noopTokenStore) RevokeToken(context.Context, uuid.UUID, time.Time) error {
+	return nil
+}
+
+func (noopTokenStore) RevokeTokenFamily(context.Context, uuid.UUID) error {
+	return nil
+}
+
+func (noopTokenStore) IsTokenRevoked(context.Context, uuid.UUID, uuid.UUID) (bool, error) {
+	return false, nil
+}
+
+type authFixture struct {
+	router *gin.Engine
+	keys   map[string]string // nama -> raw key
+	tokens map[entity.Role]string
+}
+
+func newAuthFixture(t *testing.T) authFixture {
+	t.Helper()
+	gin.SetMode(gin.TestMode)
+	ctx := context.Background()
+
+	repo := &memoryAPIKeyRepo{keys: map[string]*entity.APIKey{}}
+	apiKeyService := service.NewAPIKeyService(repo, zap.NewNop())
+
+	mint := func(name string, scopes ...string) (string, uuid.UUID) {
+		res, err := apiKeyService.MintAPIKey(ctx, dto.MintAPIKeyRequest{Name: name, Scopes: scopes, ExpiresIn: time.Hour})
+		if err != nil {
+			t.Fatalf("MintAPIKey %s: %v", name, err)
+		}
+		return res.Key, res.ID
+	}
+
+	keys := map[string]string{}
+	keys["tasks"], _ = mint("tasks", constants.ENUM_PERMISSION_TASKS_READ)
+	keys["summaries"], _ = mint("summaries", constants.ENUM_PERMISSION_SUMMARIES_READ)
+
+	var expiredID, revokedID uuid.UUID
+	keys["expired"], expiredID = mint("expired", constants.ENUM_PERMISSION_TASKS_READ)
+	past := time.Now().Add(-time.Minute)
+	repo.byID(expiredID).ExpiresAt = &past
+
+	keys["revoked"], revokedID = mint("revoked", constants.ENUM_PERMISSION_TASKS_READ)
+	if err := apiKeyService.RevokeAPIKey(ctx, revokedID.String()); err != nil {
+		t.Fatalf("RevokeAPIKey: %v", err)
+	}
+
+	jwtService, err := jwt.NewJWT(config.JWTConfig{
+		Secret:     "rahasia-uji",
+		Issuer:     constants.ENUM_JWT_DEFAULT_ISSUER,
+		AccessTTL:  time.Minute,
+		RefreshTTL: time.Hour,
+	}, noopTokenStore{})
+	if err != nil {
+		t.Fatalf("NewJWT: %v", err)
+	}
+
+	tokens := map[entity.Role]string{}
+	for _, role := range []entity.Role{entity.ADMIN, entity.STUDENT} {
+		access, _, err := jwtService.GenerateToken(ctx, uuid.NewString(), string(role))
+		if err != nil {
+			t.Fatalf("GenerateToken: %v", err)
+		}
+		tokens[role] = access
+	}
+
+	authorizationService := service.NewAuthorizationService(nil)
+	router := gin.New()
+	identity := func(ctx *gin.Context) {
+		ctx.JSON(http.StatusOK, gin.H{"api_key_id": ctx.GetString("api_key_id"), "user_id": ctx.GetString("user_id")})
+	}
+	router.GET("/tasks", AuthenticationOrAPIKey(jwtService, apiKeyService),
+		Authorize(authorizationService, constants.ENUM_PERMISSION_TASKS_READ), identity)
+	// cukup salah satu permission
+	router.GET("/any", AuthenticationOrAPIKey(jwtService, apiKeyService),
+		Authorize(authorizationService, constants.ENUM_PERMISSION_TASKS_RETRY, constants.ENUM_PERMISSION_SUMMARIES_READ), identity)
+
+	return authFixture{router: router, keys: keys, tokens: tokens}
+}
+
+func TestAuthenticationOrAPIKeyAndScopes(t *testing.T) {
+	f := newAuthFixture(t)
+
+	tests := []struct {
+		name       string
+		path       string
+		headers    map[string]string
+		wantStatus int
+		wantCode   string
+		wantAPIKey bool
+	}{
+		{name: "api key header with scope", path: "/tasks", headers: map[string]string{constants.ENUM_HEADER_API_KEY: f.keys["tasks"]}, wantStatus: http.StatusOK, wantAPIKey: true},
+		{name: "wsk_ bearer fallback", path: "/tasks", headers: map[string]string{"Authorization": "Bearer " + f.keys["tasks"]}, wantStatus: http.StatusOK, wantAPIKey: true},
+		{name: "api key header wins over jwt", path: "/tasks", headers: map[string]string{constants.ENUM_HEADER_API_KEY: f.keys["tasks"], "Authorization": "Bearer " + f.tokens[entity.STUDENT]}, wantStatus: http.StatusOK, wantAPIKey: true},
+		{name: "api key missing scope", path: "/tasks", headers: map[string]string{constants.ENUM_HEADER_API_KEY: f.keys["summaries"]}, wantStatus: http.StatusForbidden, wantCode: dto.ErrAccessDenied.Code},
+		{name: "wsk_ bearer missing scope", path: "/tasks", headers: map[string]string{"Authorization": "Bearer " + f.keys["summaries"]}, wantStatus: http.StatusForbidden, wantCode: dto.ErrAccessDenied.Code},
+		{name: "api key with one of the scopes", path: "/any", headers: map[string]string{constants.ENUM_HEADER_API_KEY: f.keys["summaries"]}, wantStatus: http.StatusOK, wantAPIKey: true},
+		{name: "expired api key", path: "/tasks", headers: map[string]string{constants.ENUM_HEADER_API_KEY: f.keys["expired"]}, wantStatus: http.StatusUnauthorized, wantCode: dto.ErrAPIKeyExpired.Code},
+		{name: "revoked api key", path: "/tasks", headers: map[string]string{constants.ENUM_HEADER_API_KEY: f.keys["revoked"]}, wantStatus: http.StatusUnauthorized, wantCode: dto.ErrAPIKeyExpired.Code},
+		{name: "revoked wsk_ bearer", path: "/tasks", headers: map[string]string{"Authorization": "Bearer " + f.keys["revoked"]}, wantStatus: http.StatusUnauthorized, wantCode: dto.ErrAPIKeyExpired.Code},
+		{name: "unknown api key", path: "/tasks", headers: map[string]string{constants.ENUM_HEADER_API_KEY: constants.ENUM_API_KEY_PREFIX + "tidakada"}, wantStatus: http.StatusUnauthorized, wantCode: dto.ErrAPIKeyInvalid.Code},
+		{name: "api key header without prefix", path: "/tasks", headers: map[string]string{constants.ENUM_HEADER_API_KEY: "tanpa-prefix"}, wantStatus: http.StatusUnauthorized, wantCode: dto.ErrAPIKeyInvalid.Code},
+		{name: "jwt role with permission", path: "/tasks", headers: map[string]string{"Authorization": "Bearer " + f.tokens[entity.ADMIN]}, wantStatus: http.StatusOK},
+		{name: "jwt role without permission", path: "/tasks", headers: map[string]string{"Authorization": "Bearer " + f.tokens[entity.STUDENT]}, wantStatus: http.StatusForbidden, wantCode: dto.ErrAccessDenied.Code},
+		{name: "invalid jwt", path: "/tasks", headers: map[string]string{"Authorization": "Bearer bukan.jwt.valid"}, wantStatus: http.StatusUnauthorized, wantCode: dto.ErrTokenInvalid.Code},
+		{name: "no credentials", path: "/tasks", wantStatus: http.StatusUnauthorized, wantCode: dto.ErrTokenNotFound.Code},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, tt.path, nil)
+			for k, v := range tt.headers {
+				req.Header.Set(k, v)
+			}
+			rec := httptest.NewRecorder()
+			f.router.ServeHTTP(rec, req)
+
+			if rec.Code != tt.wantStatus {
+				t.Fatalf("status = %d, want %d (body: %s)", rec.Code, tt.wantStatus, rec.Body)
+			}
+
+			if tt.wantStatus != http.StatusOK {
+				var res response.Response
+				if err := json.Unmarshal(rec.Body.Bytes(), &res); err != nil {
+					t.Fatalf("decode error response: %v", err)
+				}
+				if res.Code != tt.wantCode {
+					t.Errorf("code = %q, want %q", res.Code, tt.wantCode)
+				}
+				return
+			}
+
+			var identity map[string]string
+			if err := json.Unmarshal(rec.Body.Bytes(), &identity); err != nil {
+				t.Fatalf("decode identity: %v", err)
+			}
+			if got := identity["api_key_id"] != ""; got != tt.wantAPIKey {
+				t.Errorf("authenticated by api key = %v, want %v", got, tt.wantAPIKey)
+			}
+			if !tt.wantAPIKey && identity["user_id"] == "" {
+				t.Error("jwt request without user_id")
+			}
+		})
+	}
+}
@@ -0,0 +1,81 @@
+package middleware
+
+import (
+	"errors"
+	"slices"
+
+	"github.com/Amierza/worker-service/dto"
+	"github.com/Amierza/worker-service/i18n"
+	"github.com/Amierza/worker-service/response"
+	"github.com/Amierza/worker-service/service"
+	"github.com/gin-gonic/gin"
+)
+
+// Authorize harus dipasang setelah Authentication; request ditolak 403 jika role (JWT) atau
+// scope (API key) tidak punya salah satu permission.
+func Authorize(authorizationService service.IAuthorizationService, permissions ...string) gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		if ctx.GetString("api_key_id") != "" {
+			scopes := ctx.GetStringSlice("scopes")
+			for _, permission := range permissions {
+				if slices.Contains(scopes, permission) {
+					ctx.Next()
+					return
+				}
+			}
+
+			abortAccessDenied(ctx)
+			return
+		}
+
+		role := ctx.GetString("role")
+		for _, permission := range permissions {
+			if authorizationService.HasPermission(role, permission) {
+				ctx.Next()
+				return
+			}
+		}
+
+		abortAccessDenied(ctx)
+	}
+}
+
+// SessionAccess memastikan user boleh mengakses sesi pada path param yang diberikan.
+func SessionAccess(authorizationService service.IAuthorizationService, param string) gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		err := authorizationService.CanAccessSession(ctx, ctx.GetString("user_id"), ctx.GetString("role"), ctx.Param(param))
+		if err != nil {
+			abortAuthorizationError(ctx, err)
+			return
+		}
+
+		ctx.Next()
+	}
+}
+
+// ThesisAccess memastikan user boleh mengakses thesis pada path param yang diberikan.
+func ThesisAccess(authorizationService service.IAuthorizationService, param string) gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		err := authorizationService.CanAccessThesis(ctx, ctx.GetString("user_id"), ctx.GetString("role"), ctx.Param(param))
+		if err != nil {
+			abortAuthorizationError(ctx, err)
+			return
+		}
+
+		ctx.Next()
+	}
+}
+
+func abortAccessDenied(ctx *gin.Context) {
+	ctx.AbortWithStatusJSON(response.BuildResponseError(i18n.FromContext(ctx), dto.MESSAGE_FAILED_ACCESS_DENIED, dto.ErrAccessDenied))
+}
+
+func abortAuthorizationError(ctx *gin.Context, err error) {
+	if errors.Is(err, dto.ErrAccessDenied) {
+		abortAccessDenied(ctx)
+		return
+	}
+
+	_ = ctx.Error(err)
+	ctx.AbortWithStatusJSON(response.BuildResponseError(i18n.FromContext(ctx), dto.MESSAGE_FAILED_PROSES_REQUEST, err))
+}
@@ -1,23 +1,200 @@
 package middleware
 
 import (
+	"errors"
+	"fmt"
 	"net/http"
+	"net/url"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
 
 	"github.com/gin-gonic/gin"
 )
 
-func CORSMiddleware() gin.HandlerFunc {
+var ErrCORSWildcardWithCredentials = errors.New(`cors: allowed origin "*" cannot be combined with allow credentials`)
+
+type (
+	// CORSConfig adalah satu kebijakan CORS, diisi dari config.CORSConfig. AllowedOrigins menerima
+	// origin persis (https://app.example.com), wildcard subdomain (https://*.example.com) atau "*".
+	CORSConfig struct {
+		AllowedOrigins   []string
+		AllowedHeaders   []string
+		AllowedMethods   []string
+		ExposedHeaders   []string
+		AllowCredentials bool
+		MaxAge           time.Duration
+	}
+
+	corsPolicy struct {
+		config  CORSConfig
+		anyOrig bool
+		methods string
+		headers string
+		exposed string
+		maxAge  string
+	}
+)
+
+// Validate menolak origin "*" bersama credentials; origin tidak pernah di-echo sebagai gantinya
+// karena itu sama saja mengizinkan semua origin membaca respons ber-cookie.
+func (c CORSConfig) Validate() error {
+	if c.AllowCredentials && slices.Contains(c.AllowedOrigins, "*") {
+		return ErrCORSWildcardWithCredentials
+	}
+
+	return nil
+}
+
+// CORSMiddleware memilih kebijakan berdasarkan prefix path terpanjang di routeConfigs,
+// selain itu memakai defaultConfig. Dipasang global agar preflight ke route tanpa handler OPTIONS tetap terjawab.
+// Konfigurasi yang tidak valid dikembalikan sebagai error agar server gagal start.
+func CORSMiddleware(defaultConfig CORSConfig, routeConfigs map[string]CORSConfig) (gin.HandlerFunc, error) {
+	if err := defaultConfig.Validate(); err != nil {
+		return nil, err
+	}
+	defaultPolicy := newCORSPolicy(defaultConfig)
+
+	prefixes := make([]string, 0, len(routeConfigs))
+	policies := make(map[string]*corsPolicy, len(routeConfigs))
+	for prefix, config := range routeConfigs {
+		if err := config.Validate(); err != nil {
+			return nil, fmt.Errorf("%s: %w", prefix, err)
+		}
+		prefixes = append(prefixes, prefix)
+		policies[prefix] = newCORSPolicy(config)
+	}
+	slices.SortFunc(prefixes, func(a, b string) int { return len(b) - len(a) })
+
 	return func(c *gin.Context) {
-		c.Header("Access-Control-Allow-Origin", "*")
-		c.Header("Access-Control-Allow-Credentials", "true")
-		c.Header("Access-Control-Allow-Headers", "Content-Type, Content-Length, Accept-Encoding, X-CSRF-Token, Authorization, accept, origin, Cache-Control, X-Requested-With")
-		c.Header("Access-Control-Allow-Methods", "POST, HEAD, PATCH, OPTIONS, GET, PUT, DELETE")
+		policy := defaultPolicy
+		for _, prefix := range prefixes {
+			if strings.HasPrefix(c.Request.URL.Path, prefix) {
+				policy = policies[prefix]
+				break
+			}
+		}
+
+		policy.handle(c)
+	}, nil
+}
+
+func newCORSPolicy(config CORSConfig) *corsPolicy {
+	methods := config.AllowedMethods
+	if len(methods) == 0 {
+		methods = []string{http.MethodGet, http.MethodHead, http.MethodOptions}
+	}
+
+	policy := &corsPolicy{
+		config:  config,
+		anyOrig: slices.Contains(config.AllowedOrigins, "*"),
+		methods: strings.Join(upperAll(methods), ", "),
+		headers: strings.Join(config.AllowedHeaders, ", "),
+		exposed: strings.Join(config.ExposedHeaders, ", "),
+	}
+	if config.MaxAge > 0 {
+		policy.maxAge = strconv.Itoa(int(config.MaxAge.Seconds()))
+	}
+
+	return policy
+}
+
+func (p *corsPolicy) handle(c *gin.Context) {
+	origin := c.GetHeader("Origin")
+	preflight := c.Request.Method == http.MethodOptions && c.GetHeader("Access-Control-Request-Method") != ""
+
+	// respons bergantung pada Origin, cache/proxy tidak boleh menyajikan ulang ke origin lain
+	c.Writer.Header().Add("Vary", "Origin")
+	if preflight {
+		c.Writer.Header().Add("Vary", "Access-Control-Request-Method")
+		c.Writer.Header().Add("Vary", "Access-Control-Request-Headers")
+	}
+
+	if origin == "" {
+		c.Next()
+		return
+	}
 
-		if c.Request.Method == http.MethodOptions {
-			c.AbortWithStatus(204)
+	if !p.isOriginAllowed(origin) {
+		if preflight {
+			c.AbortWithStatus(http.StatusForbidden)
 			return
 		}
+		c.Next()
+		return
+	}
+
+	// kombinasi "*" dan credentials sudah ditolak Validate
+	if p.anyOrig {
+		c.Header("Access-Control-Allow-Origin", "*")
+	} else {
+		c.Header("Access-Control-Allow-Origin", origin)
+	}
+	if p.config.AllowCredentials {
+		c.Header("Access-Control-Allow-Credentials", "true")
+	}
+	if p.exposed != "" {
+		c.Header("Access-Control-Expose-Headers", p.exposed)
+	}
 
+	if !preflight {
 		c.Next()
+		return
+	}
+
+	c.Header("Access-Control-Allow-Methods", p.methods)
+	if p.headers != "" {
+		c.Header("Access-Control-Allow-Headers", p.headers)
+	}
+	if p.maxAge != "" {
+		c.Header("Access-Control-Max-Age", p.maxAge)
+	}
+	c.AbortWithStatus(http.StatusNoContent)
+}
+
+func (p *corsPolicy) isOriginAllowed(origin string) bool {
+	if p.anyOrig {
+		return true
+	}
+
+	originURL, err := url.Parse(origin)
+	if err != nil || originURL.Scheme == "" || originURL.Host == "" {
+		return false
+	}
+
+	for _, allowed := range p.config.AllowedOrigins {
+		if strings.EqualFold(allowed, origin) {
+			return true
+		}
+
+		if matchWildcardOrigin(allowed, originURL) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// matchWildcardOrigin mencocokkan pola https://*.example.com; minimal satu label subdomain
+// dan scheme serta port harus sama.
+func matchWildcardOrigin(pattern string, origin *url.URL) bool {
+	scheme, host, ok := strings.Cut(pattern, "://*.")
+	if !ok || !strings.EqualFold(scheme, origin.Scheme) {
+		return false
+	}
+
+	originHost := strings.ToLower(origin.Host)
+	suffix := "." + strings.ToLower(host)
+
+	return strings.HasSuffix(originHost, suffix) && len(originHost) > len(suffix)
+}
+
+func upperAll(values []string) []string {
+	upper := make([]string, len(values))
+	for i, v := range values {
+		upper[i] = strings.ToUpper(v)
 	}
+
+	return upper
 }
@@ -0,0 +1,79 @@
+package middleware
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+)
+
+func TestCORSMiddlewareRejectsWildcardWithCredentials(t *testing.T) {
+	tests := []struct {
+		name    string
+		def     CORSConfig
+		routes  map[string]CORSConfig
+		wantErr bool
+	}{
+		{name: "exact origin with credentials", def: CORSConfig{AllowedOrigins: []string{"https://app.example.com"}, AllowCredentials: true}},
+		{name: "wildcard without credentials", def: CORSConfig{AllowedOrigins: []string{"*"}}},
+		{name: "wildcard with credentials", def: CORSConfig{AllowedOrigins: []string{"https://app.example.com", "*"}, AllowCredentials: true}, wantErr: true},
+		{name: "route wildcard with credentials", routes: map[string]CORSConfig{"/uploads": {AllowedOrigins: []string{"*"}, AllowCredentials: true}}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := CORSMiddleware(tt.def, tt.routes)
+			if got := errors.Is(err, ErrCORSWildcardWithCredentials); got != tt.wantErr {
+				t.Fatalf("CORSMiddleware error = %v, want wildcard error %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestCORSMiddlewareAllowOrigin(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	cors, err := CORSMiddleware(
+		CORSConfig{AllowedOrigins: []string{"https://app.example.com", "https://*.kampus.ac.id"}, AllowCredentials: true},
+		map[string]CORSConfig{"/uploads": {AllowedOrigins: []string{"*"}}},
+	)
+	if err != nil {
+		t.Fatalf("CORSMiddleware: %v", err)
+	}
+
+	router := gin.New()
+	router.Use(cors)
+	router.GET("/*path", func(ctx *gin.Context) { ctx.Status(http.StatusOK) })
+
+	tests := []struct {
+		name            string
+		path            string
+		origin          string
+		wantOrigin      string
+		wantCredentials string
+	}{
+		{name: "exact origin", path: "/api", origin: "https://app.example.com", wantOrigin: "https://app.example.com", wantCredentials: "true"},
+		{name: "wildcard subdomain", path: "/api", origin: "https://sia.kampus.ac.id", wantOrigin: "https://sia.kampus.ac.id", wantCredentials: "true"},
+		{name: "unknown origin", path: "/api", origin: "https://evil.example.net"},
+		// "*" tidak pernah di-echo menjadi origin peminta
+		{name: "uploads wildcard", path: "/uploads/a.pdf", origin: "https://evil.example.net", wantOrigin: "*"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, tt.path, nil)
+			req.Header.Set("Origin", tt.origin)
+			rec := httptest.NewRecorder()
+			router.ServeHTTP(rec, req)
+
+			if got := rec.Header().Get("Access-Control-Allow-Origin"); got != tt.wantOrigin {
+				t.Errorf("Access-Control-Allow-Origin = %q, want %q", got, tt.wantOrigin)
+			}
+			if got := rec.Header().Get("Access-Control-Allow-Credentials"); got != tt.wantCredentials {
+				t.Errorf("Access-Control-Allow-Credentials = %q, want %q", got, tt.wantCredentials)
+			}
+		})
+	}
+}
@@ -0,0 +1,35 @@
+package middleware
+
+import (
+	"github.com/Amierza/worker-service/i18n"
+	"github.com/Amierza/worker-service/service"
+	"github.com/gin-gonic/gin"
+)
+
+// Locale menentukan bahasa response: locale di profil user (jika sudah terautentikasi), lalu
+// Accept-Language yang didukung, lalu i18n.DefaultLocale. Dipasang setelah Authentication.
+func Locale(userService service.IUserService) gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		var locale string
+
+		if userID := ctx.GetString("user_id"); userID != "" {
+			profileLocale, err := userService.GetUserLocale(ctx, userID)
+			if err != nil {
+				// gagal membaca profil tidak menggagalkan request, cukup pakai Accept-Language
+				_ = ctx.Error(err)
+			}
+			locale = profileLocale
+		}
+
+		if locale == "" {
+			locale = i18n.FromAcceptLanguage(ctx.GetHeader("Accept-Language"))
+		}
+		if locale == "" {
+			locale = i18n.DefaultLocale
+		}
+
+		ctx.Set(i18n.ContextKey, locale)
+		ctx.Header("Content-Language", locale)
+		ctx.Next()
+	}
+}
@@ -0,0 +1,68 @@
+package middleware
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/Amierza/worker-service/i18n"
+	"github.com/gin-gonic/gin"
+)
+
+// fakeUserService mengembalikan locale profil yang sudah dinormalisasi, atau err.
+type fakeUserService struct {
+	locale string
+	err    error
+}
+
+func (s fakeUserService) GetUserLocale(context.Context, string) (string, error) {
+	return s.locale, s.err
+}
+
+func TestLocalePrefersProfileOverAcceptLanguage(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	tests := []struct {
+		name           string
+		userID         string
+		profile        fakeUserService
+		acceptLanguage string
+		want           string
+	}{
+		{name: "profile wins", userID: "user-1", profile: fakeUserService{locale: i18n.LocaleID}, acceptLanguage: "en-US,en;q=0.9", want: i18n.LocaleID},
+		{name: "profile en", userID: "user-1", profile: fakeUserService{locale: i18n.LocaleEN}, acceptLanguage: "id", want: i18n.LocaleEN},
+		{name: "unsupported profile falls back to header", userID: "user-1", profile: fakeUserService{locale: ""}, acceptLanguage: "en", want: i18n.LocaleEN},
+		{name: "profile error falls back to header", userID: "user-1", profile: fakeUserService{err: errors.New("db down")}, acceptLanguage: "en", want: i18n.LocaleEN},
+		{name: "anonymous uses header", acceptLanguage: "en", want: i18n.LocaleEN},
+		{name: "default", want: i18n.DefaultLocale},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			router := gin.New()
+			router.GET("/", func(ctx *gin.Context) {
+				if tt.userID != "" {
+					ctx.Set("user_id", tt.userID)
+				}
+			}, Locale(tt.profile), func(ctx *gin.Context) {
+				ctx.String(http.StatusOK, i18n.FromContext(ctx))
+			})
+
+			req := httptest.NewRequest(http.MethodGet, "/", nil)
+			if tt.acceptLanguage != "" {
+				req.Header.Set("Accept-Language", tt.acceptLanguage)
+			}
+			rec := httptest.NewRecorder()
+			router.ServeHTTP(rec, req)
+
+			if got := rec.Body.String(); got != tt.want {
+				t.Errorf("locale = %q, want %q", got, tt.want)
+			}
+			if got := rec.Header().Get("Content-Language"); got != tt.want {
+				t.Errorf("Content-Language = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,88 @@
+package middleware
+
+import (
+	"net/http"
+	"runtime/debug"
+	"time"
+
+	"github.com/Amierza/worker-service/dto"
+	"github.com/Amierza/worker-service/i18n"
+	"github.com/Amierza/worker-service/response"
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+// AccessLog mencatat setiap request lewat zap. Dipasang setelah RequestID;
+// user_id terisi jika route memakai Authentication.
+func AccessLog(logger *zap.Logger) gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		start := time.Now()
+		ctx.Next()
+
+		route := ctx.FullPath()
+		if route == "" {
+			route = "unmatched"
+		}
+
+		status := ctx.Writer.Status()
+		fields := []zap.Field{
+			zap.String("request_id", ctx.GetString("request_id")),
+			zap.String("method", ctx.Request.Method),
+			zap.String("route", route),
+			zap.String("path", ctx.Request.URL.Path),
+			zap.Int("status", status),
+			zap.Duration("latency", time.Since(start)),
+			zap.String("client_ip", ctx.ClientIP()),
+			zap.Int("response_size", ctx.Writer.Size()),
+		}
+		if userID := ctx.GetString("user_id"); userID != "" {
+			fields = append(fields, zap.String("user_id", userID))
+		}
+		if apiKeyID := ctx.GetString("api_key_id"); apiKeyID != "" {
+			fields = append(fields, zap.String("api_key_id", apiKeyID))
+		}
+		if len(ctx.Errors) > 0 {
+			fields = append(fields, zap.String("errors", ctx.Errors.String()))
+		}
+
+		level := zapcore.InfoLevel
+		switch {
+		case status >= http.StatusInternalServerError:
+			level = zapcore.ErrorLevel
+		case status >= http.StatusBadRequest:
+			level = zapcore.WarnLevel
+		}
+
+		logger.Log(level, "http request", fields...)
+	}
+}
+
+// Recovery mengubah panic di handler menjadi response JSON 500 dan mencatat stack trace-nya.
+func Recovery(logger *zap.Logger) gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		defer func() {
+			recovered := recover()
+			if recovered == nil {
+				return
+			}
+
+			// koneksi client terputus, tidak perlu menulis response
+			if recovered == http.ErrAbortHandler {
+				panic(recovered)
+			}
+
+			logger.Error("panic recovered",
+				zap.String("request_id", ctx.GetString("request_id")),
+				zap.String("method", ctx.Request.Method),
+				zap.String("route", ctx.FullPath()),
+				zap.Any("panic", recovered),
+				zap.ByteString("stack", debug.Stack()),
+			)
+
+			ctx.AbortWithStatusJSON(response.BuildResponseError(i18n.FromContext(ctx), dto.MESSAGE_FAILED_PROSES_REQUEST, dto.ErrInternalServer))
+		}()
+
+		ctx.Next()
+	}
+}
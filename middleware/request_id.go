@@ -0,0 +1,38 @@
+package middleware
+
+import (
+	"regexp"
+
+	"github.com/Amierza/worker-service/constants"
+	"github.com/Amierza/worker-service/logger"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+// requestIDPattern membatasi X-Request-ID dari client agar tidak bisa menyisipkan isi log sembarangan.
+var requestIDPattern = regexp.MustCompile(`^[A-Za-z0-9._:-]{1,128}$`)
+
+// RequestID memakai X-Request-ID dari client jika valid, selain itu membuat UUID baru.
+// ID disimpan di context gin ("request_id") dan dikembalikan di header response, serta
+// menjadi correlation id untuk task yang di-publish selama request tersebut. Context request
+// juga membawa base logger berisi request_id & correlation_id, sehingga log statement DB
+// (logger.GormLogger) dan service yang memakai logger.FromContext ikut membawa keduanya.
+func RequestID(base *zap.Logger) gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		requestID := ctx.GetHeader(constants.ENUM_HEADER_REQUEST_ID)
+		if !requestIDPattern.MatchString(requestID) {
+			requestID = uuid.NewString()
+		}
+
+		ctx.Set("request_id", requestID)
+		reqCtx := logger.WithCorrelationID(ctx.Request.Context(), requestID)
+		reqCtx = logger.WithContext(reqCtx, base.With(
+			zap.String("request_id", requestID),
+			zap.String("correlation_id", requestID),
+		))
+		ctx.Request = ctx.Request.WithContext(reqCtx)
+		ctx.Header(constants.ENUM_HEADER_REQUEST_ID, requestID)
+		ctx.Next()
+	}
+}
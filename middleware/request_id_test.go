@@ -0,0 +1,68 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/Amierza/worker-service/constants"
+	"github.com/Amierza/worker-service/logger"
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zaptest/observer"
+)
+
+func TestRequestIDAttachesContextLogger(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	tests := []struct {
+		name      string
+		header    string
+		wantReuse bool
+	}{
+		{name: "client request id", header: "req-123", wantReuse: true},
+		{name: "invalid client request id", header: "bukan valid\n"},
+		{name: "no request id"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			core, logs := observer.New(zap.InfoLevel)
+
+			router := gin.New()
+			router.Use(RequestID(zap.New(core)))
+			router.GET("/", func(ctx *gin.Context) {
+				// logger.GormLogger membaca logger yang sama dari context request
+				logger.FromContext(ctx.Request.Context(), zap.NewNop()).Info("query")
+				ctx.String(http.StatusOK, logger.CorrelationIDFromContext(ctx.Request.Context()))
+			})
+
+			req := httptest.NewRequest(http.MethodGet, "/", nil)
+			if tt.header != "" {
+				req.Header.Set(constants.ENUM_HEADER_REQUEST_ID, tt.header)
+			}
+			rec := httptest.NewRecorder()
+			router.ServeHTTP(rec, req)
+
+			requestID := rec.Header().Get(constants.ENUM_HEADER_REQUEST_ID)
+			if requestID == "" {
+				t.Fatal("missing request id header")
+			}
+			if tt.wantReuse != (requestID == tt.header) {
+				t.Errorf("request id = %q, client sent %q", requestID, tt.header)
+			}
+			if got := rec.Body.String(); got != requestID {
+				t.Errorf("correlation id = %q, want %q", got, requestID)
+			}
+
+			entries := logs.All()
+			if len(entries) != 1 {
+				t.Fatalf("got %d log entries, want 1", len(entries))
+			}
+			fields := entries[0].ContextMap()
+			if fields["request_id"] != requestID || fields["correlation_id"] != requestID {
+				t.Errorf("log fields = %v, want request_id & correlation_id %q", fields, requestID)
+			}
+		})
+	}
+}
@@ -0,0 +1,152 @@
+package migrations
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"sort"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// kunci advisory postgres supaya hanya satu proses yang menjalankan migrasi dalam satu waktu
+const migrationLockKey int64 = 7_310_250_001
+
+type (
+	Migration struct {
+		Version int
+		Name    string
+		Up      func(tx *gorm.DB) error
+		Down    func(tx *gorm.DB) error
+	}
+
+	SchemaMigration struct {
+		Version   int       `gorm:"primaryKey;autoIncrement:false"`
+		Name      string    `gorm:"not null"`
+		AppliedAt time.Time `gorm:"not null"`
+	}
+)
+
+func (SchemaMigration) TableName() string {
+	return "schema_migrations"
+}
+
+// Migrate menjalankan semua migrasi yang belum diterapkan, berurutan berdasarkan versi.
+// Seluruh proses berjalan dalam satu transaksi sehingga gagal di tengah = tidak ada yang berubah.
+func Migrate(ctx context.Context, db *gorm.DB) error {
+	return withMigrationLock(ctx, db, func(tx *gorm.DB) error {
+		applied, err := appliedVersions(tx)
+		if err != nil {
+			return err
+		}
+
+		for _, m := range sortedMigrations() {
+			if applied[m.Version] {
+				continue
+			}
+
+			log.Printf("migrating up %d_%s", m.Version, m.Name)
+			if err := m.Up(tx); err != nil {
+				return fmt.Errorf("migration %d_%s up: %w", m.Version, m.Name, err)
+			}
+
+			if err := tx.Create(&SchemaMigration{
+				Version:   m.Version,
+				Name:      m.Name,
+				AppliedAt: time.Now(),
+			}).Error; err != nil {
+				return fmt.Errorf("record migration %d_%s: %w", m.Version, m.Name, err)
+			}
+		}
+
+		return nil
+	})
+}
+
+// Rollback membatalkan sejumlah steps migrasi terakhir yang sudah diterapkan.
+func Rollback(ctx context.Context, db *gorm.DB, steps int) error {
+	if steps < 1 {
+		return fmt.Errorf("rollback steps must be at least 1, got %d", steps)
+	}
+
+	return withMigrationLock(ctx, db, func(tx *gorm.DB) error {
+		applied, err := appliedVersions(tx)
+		if err != nil {
+			return err
+		}
+
+		ordered := sortedMigrations()
+		for i := len(ordered) - 1; i >= 0 && steps > 0; i-- {
+			m := ordered[i]
+			if !applied[m.Version] {
+				continue
+			}
+
+			log.Printf("migrating down %d_%s", m.Version, m.Name)
+			if err := m.Down(tx); err != nil {
+				return fmt.Errorf("migration %d_%s down: %w", m.Version, m.Name, err)
+			}
+
+			if err := tx.Delete(&SchemaMigration{}, "version = ?", m.Version).Error; err != nil {
+				return fmt.Errorf("unrecord migration %d_%s: %w", m.Version, m.Name, err)
+			}
+			steps--
+		}
+
+		return nil
+	})
+}
+
+func withMigrationLock(ctx context.Context, db *gorm.DB, fn func(tx *gorm.DB) error) error {
+	return db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		// lock dilepas otomatis saat transaksi commit / rollback
+		if err := tx.Exec("SELECT pg_advisory_xact_lock(?)", migrationLockKey).Error; err != nil {
+			return fmt.Errorf("acquire migration lock: %w", err)
+		}
+
+		if err := tx.AutoMigrate(&SchemaMigration{}); err != nil {
+			return fmt.Errorf("create schema_migrations table: %w", err)
+		}
+
+		return fn(tx)
+	})
+}
+
+func appliedVersions(tx *gorm.DB) (map[int]bool, error) {
+	var versions []int
+	if err := tx.Model(&SchemaMigration{}).Pluck("version", &versions).Error; err != nil {
+		return nil, fmt.Errorf("read schema version: %w", err)
+	}
+
+	applied := make(map[int]bool, len(versions))
+	for _, v := range versions {
+		applied[v] = true
+	}
+
+	return applied, nil
+}
+
+func sortedMigrations() []Migration {
+	ordered := make([]Migration, len(migrations))
+	copy(ordered, migrations)
+	sort.Slice(ordered, func(i, j int) bool {
+		return ordered[i].Version < ordered[j].Version
+	})
+
+	return ordered
+}
+
+// execStatements menjalankan DDL satu statement per Exec, driver pgx tidak menerima
+// beberapa statement dalam satu query.
+func execStatements(statements ...string) func(tx *gorm.DB) error {
+	return func(tx *gorm.DB) error {
+		for _, stmt := range statements {
+			if err := tx.Exec(stmt).Error; err != nil {
+				return err
+			}
+		}
+
+		return nil
+	}
+}
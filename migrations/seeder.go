@@ -0,0 +1,69 @@
+package migrations
+
+import (
+	"context"
+	"embed"
+	"encoding/json"
+	"fmt"
+	"log"
+
+	"github.com/Amierza/worker-service/entity"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+//go:embed data/*.json
+var fixtures embed.FS
+
+// Seed mengisi data master dari file fixture di migrations/data.
+// Aman dijalankan berulang kali: baris dengan id yang sudah ada dilewati.
+func Seed(ctx context.Context, db *gorm.DB) error {
+	return db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := seedFixture[entity.Faculty](tx, "faculties.json"); err != nil {
+			return err
+		}
+		if err := seedFixture[entity.StudyProgram](tx, "study_programs.json"); err != nil {
+			return err
+		}
+		if err := seedFixture[entity.Lecturer](tx, "lecturers.json"); err != nil {
+			return err
+		}
+		if err := seedFixture[entity.Student](tx, "students.json"); err != nil {
+			return err
+		}
+		if err := seedFixture[entity.Thesis](tx, "theses.json"); err != nil {
+			return err
+		}
+		if err := seedFixture[entity.ThesisSupervisor](tx, "thesis_supervisors.json"); err != nil {
+			return err
+		}
+
+		return nil
+	})
+}
+
+func seedFixture[T any](tx *gorm.DB, file string) error {
+	raw, err := fixtures.ReadFile("data/" + file)
+	if err != nil {
+		return fmt.Errorf("read fixture %s: %w", file, err)
+	}
+
+	var rows []T
+	if err := json.Unmarshal(raw, &rows); err != nil {
+		return fmt.Errorf("decode fixture %s: %w", file, err)
+	}
+
+	if len(rows) == 0 {
+		return nil
+	}
+
+	if err := tx.
+		Clauses(clause.OnConflict{DoNothing: true}).
+		Omit(clause.Associations).
+		Create(&rows).Error; err != nil {
+		return fmt.Errorf("seed %s: %w", file, err)
+	}
+
+	log.Printf("seeded %d rows from %s", len(rows), file)
+	return nil
+}
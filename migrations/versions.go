@@ -0,0 +1,381 @@
+package migrations
+
+// Daftar migrasi. Jangan ubah migrasi yang sudah dirilis, tambahkan versi baru.
+//
+// Setiap versi dibekukan sebagai DDL eksplisit (bukan AutoMigrate entity), sehingga perubahan
+// entity berikutnya tidak mengubah apa yang dibuat versi lama. IF NOT EXISTS dipertahankan agar
+// database yang tabelnya sudah dibuat backend utama tetap bisa diadopsi.
+var migrations = []Migration{
+	{
+		Version: 1,
+		Name:    "create_master_tables",
+		Up: execStatements(
+			`CREATE TABLE IF NOT EXISTS "faculties" (
+				"id" uuid,
+				"name" text NOT NULL,
+				"created_at" timestamptz,
+				"updated_at" timestamptz,
+				"deleted_at" timestamptz,
+				PRIMARY KEY ("id"),
+				CONSTRAINT "uni_faculties_name" UNIQUE ("name")
+			)`,
+			`CREATE TABLE IF NOT EXISTS "study_programs" (
+				"id" uuid,
+				"name" text NOT NULL,
+				"degree" text NOT NULL DEFAULT 's1',
+				"faculty_id" uuid,
+				"created_at" timestamptz,
+				"updated_at" timestamptz,
+				"deleted_at" timestamptz,
+				PRIMARY KEY ("id"),
+				CONSTRAINT "fk_faculties_study_programs" FOREIGN KEY ("faculty_id") REFERENCES "faculties"("id") ON DELETE CASCADE
+			)`,
+			`CREATE INDEX IF NOT EXISTS "idx_study_programs_faculty_id" ON "study_programs" ("faculty_id")`,
+			`CREATE TABLE IF NOT EXISTS "lecturers" (
+				"id" uuid,
+				"nip" text NOT NULL,
+				"name" text NOT NULL,
+				"email" text NOT NULL,
+				"total_student" bigint,
+				"study_program_id" uuid,
+				"created_at" timestamptz,
+				"updated_at" timestamptz,
+				"deleted_at" timestamptz,
+				PRIMARY KEY ("id"),
+				CONSTRAINT "fk_study_programs_lecturers" FOREIGN KEY ("study_program_id") REFERENCES "study_programs"("id") ON DELETE CASCADE,
+				CONSTRAINT "uni_lecturers_nip" UNIQUE ("nip"),
+				CONSTRAINT "uni_lecturers_email" UNIQUE ("email")
+			)`,
+			`CREATE INDEX IF NOT EXISTS "idx_lecturers_study_program_id" ON "lecturers" ("study_program_id")`,
+			`CREATE TABLE IF NOT EXISTS "students" (
+				"id" uuid,
+				"nim" text NOT NULL,
+				"name" text NOT NULL,
+				"email" text NOT NULL,
+				"study_program_id" uuid,
+				"created_at" timestamptz,
+				"updated_at" timestamptz,
+				"deleted_at" timestamptz,
+				PRIMARY KEY ("id"),
+				CONSTRAINT "fk_study_programs_students" FOREIGN KEY ("study_program_id") REFERENCES "study_programs"("id") ON DELETE CASCADE,
+				CONSTRAINT "uni_students_nim" UNIQUE ("nim"),
+				CONSTRAINT "uni_students_email" UNIQUE ("email")
+			)`,
+			`CREATE INDEX IF NOT EXISTS "idx_students_study_program_id" ON "students" ("study_program_id")`,
+			// kolom locale baru ditambahkan di versi 9
+			`CREATE TABLE IF NOT EXISTS "users" (
+				"id" uuid,
+				"identifier" text NOT NULL,
+				"role" text NOT NULL,
+				"password" text,
+				"student_id" uuid,
+				"lecturer_id" uuid,
+				"created_at" timestamptz,
+				"updated_at" timestamptz,
+				"deleted_at" timestamptz,
+				PRIMARY KEY ("id"),
+				CONSTRAINT "fk_lecturers_users" FOREIGN KEY ("lecturer_id") REFERENCES "lecturers"("id") ON DELETE CASCADE,
+				CONSTRAINT "fk_students_users" FOREIGN KEY ("student_id") REFERENCES "students"("id") ON DELETE CASCADE
+			)`,
+			`CREATE INDEX IF NOT EXISTS "idx_users_lecturer_id" ON "users" ("lecturer_id")`,
+			`CREATE INDEX IF NOT EXISTS "idx_users_student_id" ON "users" ("student_id")`,
+			`CREATE TABLE IF NOT EXISTS "theses" (
+				"id" uuid,
+				"title" text NOT NULL,
+				"description" text,
+				"progress" text,
+				"student_id" uuid,
+				"created_at" timestamptz,
+				"updated_at" timestamptz,
+				"deleted_at" timestamptz,
+				PRIMARY KEY ("id"),
+				CONSTRAINT "fk_students_theses" FOREIGN KEY ("student_id") REFERENCES "students"("id") ON DELETE CASCADE
+			)`,
+			`CREATE INDEX IF NOT EXISTS "idx_theses_student_id" ON "theses" ("student_id")`,
+			`CREATE TABLE IF NOT EXISTS "thesis_logs" (
+				"id" uuid,
+				"progress" text DEFAULT 'bab1',
+				"thesis_id" uuid,
+				"created_at" timestamptz,
+				"updated_at" timestamptz,
+				"deleted_at" timestamptz,
+				PRIMARY KEY ("id"),
+				CONSTRAINT "fk_theses_thesis_logs" FOREIGN KEY ("thesis_id") REFERENCES "theses"("id") ON DELETE CASCADE
+			)`,
+			`CREATE INDEX IF NOT EXISTS "idx_thesis_logs_thesis_id" ON "thesis_logs" ("thesis_id")`,
+			`CREATE TABLE IF NOT EXISTS "thesis_supervisors" (
+				"id" uuid,
+				"role" text NOT NULL,
+				"thesis_id" uuid,
+				"lecturer_id" uuid,
+				"created_at" timestamptz,
+				"updated_at" timestamptz,
+				"deleted_at" timestamptz,
+				PRIMARY KEY ("id"),
+				CONSTRAINT "fk_lecturers_supervisors" FOREIGN KEY ("lecturer_id") REFERENCES "lecturers"("id") ON DELETE CASCADE,
+				CONSTRAINT "fk_theses_supervisors" FOREIGN KEY ("thesis_id") REFERENCES "theses"("id") ON DELETE CASCADE
+			)`,
+			`CREATE INDEX IF NOT EXISTS "idx_thesis_supervisors_lecturer_id" ON "thesis_supervisors" ("lecturer_id")`,
+			`CREATE INDEX IF NOT EXISTS "idx_thesis_supervisors_thesis_id" ON "thesis_supervisors" ("thesis_id")`,
+		),
+		// tabel master milik backend utama dan hanya diadopsi di sini; rollback tidak boleh
+		// menghapus data service lain, sehingga versi ini tidak punya objek untuk di-drop
+		Down: execStatements(),
+	},
+	{
+		Version: 2,
+		Name:    "create_session_tables",
+		Up: execStatements(
+			`CREATE TABLE IF NOT EXISTS "sessions" (
+				"id" uuid,
+				"start_time" timestamptz,
+				"end_time" timestamptz,
+				"status" text DEFAULT 'waiting',
+				"thesis_id" uuid,
+				"user_id_owner" uuid,
+				"created_at" timestamptz,
+				"updated_at" timestamptz,
+				"deleted_at" timestamptz,
+				PRIMARY KEY ("id"),
+				CONSTRAINT "fk_theses_sessions" FOREIGN KEY ("thesis_id") REFERENCES "theses"("id") ON DELETE CASCADE,
+				CONSTRAINT "fk_users_session_owners" FOREIGN KEY ("user_id_owner") REFERENCES "users"("id") ON DELETE CASCADE
+			)`,
+			`CREATE INDEX IF NOT EXISTS "idx_sessions_user_id_owner" ON "sessions" ("user_id_owner")`,
+			`CREATE INDEX IF NOT EXISTS "idx_sessions_thesis_id" ON "sessions" ("thesis_id")`,
+			`CREATE TABLE IF NOT EXISTS "notes" (
+				"id" uuid,
+				"content" text NOT NULL,
+				"session_id" uuid,
+				"created_at" timestamptz,
+				"updated_at" timestamptz,
+				"deleted_at" timestamptz,
+				PRIMARY KEY ("id"),
+				CONSTRAINT "fk_sessions_notes" FOREIGN KEY ("session_id") REFERENCES "sessions"("id") ON DELETE CASCADE
+			)`,
+			`CREATE INDEX IF NOT EXISTS "idx_notes_session_id" ON "notes" ("session_id")`,
+			`CREATE TABLE IF NOT EXISTS "messages" (
+				"id" uuid,
+				"is_text" boolean NOT NULL,
+				"text" text,
+				"file_url" text,
+				"file_type" text,
+				"sender_role" text NOT NULL,
+				"sender_id" uuid,
+				"session_id" uuid,
+				"parent_message_id" uuid,
+				"created_at" timestamptz,
+				"updated_at" timestamptz,
+				"deleted_at" timestamptz,
+				PRIMARY KEY ("id"),
+				CONSTRAINT "fk_sessions_messages" FOREIGN KEY ("session_id") REFERENCES "sessions"("id") ON DELETE CASCADE,
+				CONSTRAINT "fk_users_messages" FOREIGN KEY ("sender_id") REFERENCES "users"("id") ON DELETE CASCADE
+			)`,
+			// kolom milik worker; tabel messages dari backend utama belum memilikinya
+			`ALTER TABLE "messages" ADD COLUMN IF NOT EXISTS "sent_at" timestamptz`,
+			`ALTER TABLE "messages" ADD COLUMN IF NOT EXISTS "is_edited" boolean NOT NULL DEFAULT false`,
+			`ALTER TABLE "messages" ADD COLUMN IF NOT EXISTS "edited_at" timestamptz`,
+			`CREATE INDEX IF NOT EXISTS "idx_messages_sent_at" ON "messages" ("sent_at")`,
+			`CREATE INDEX IF NOT EXISTS "idx_messages_parent_message_id" ON "messages" ("parent_message_id")`,
+			`CREATE INDEX IF NOT EXISTS "idx_messages_session_id" ON "messages" ("session_id")`,
+			`CREATE INDEX IF NOT EXISTS "idx_messages_sender_id" ON "messages" ("sender_id")`,
+			`CREATE TABLE IF NOT EXISTS "notifications" (
+				"id" uuid,
+				"title" text NOT NULL,
+				"message" text NOT NULL,
+				"is_read" boolean,
+				"user_id" uuid,
+				"created_at" timestamptz,
+				"updated_at" timestamptz,
+				"deleted_at" timestamptz,
+				PRIMARY KEY ("id"),
+				CONSTRAINT "fk_users_notifications" FOREIGN KEY ("user_id") REFERENCES "users"("id") ON DELETE CASCADE
+			)`,
+			`CREATE INDEX IF NOT EXISTS "idx_notifications_user_id" ON "notifications" ("user_id")`,
+		),
+		// hanya objek yang ditambahkan worker yang di-drop, tabelnya milik backend utama
+		Down: execStatements(
+			`DROP INDEX IF EXISTS "idx_messages_sent_at"`,
+			`ALTER TABLE "messages" DROP COLUMN IF EXISTS "edited_at"`,
+			`ALTER TABLE "messages" DROP COLUMN IF EXISTS "is_edited"`,
+			`ALTER TABLE "messages" DROP COLUMN IF EXISTS "sent_at"`,
+		),
+	},
+	{
+		Version: 3,
+		Name:    "create_summaries_table",
+		Up: execStatements(
+			`CREATE TABLE IF NOT EXISTS "summaries" (
+				"id" uuid,
+				"content" jsonb NOT NULL,
+				"version" bigint NOT NULL,
+				"session_id" uuid,
+				"created_at" timestamptz,
+				"updated_at" timestamptz,
+				"deleted_at" timestamptz,
+				PRIMARY KEY ("id"),
+				CONSTRAINT "fk_sessions_summaries" FOREIGN KEY ("session_id") REFERENCES "sessions"("id") ON DELETE CASCADE
+			)`,
+			`CREATE UNIQUE INDEX IF NOT EXISTS "idx_summaries_session_version" ON "summaries" ("version","session_id")`,
+		),
+		Down: execStatements(
+			`DROP TABLE IF EXISTS "summaries"`,
+		),
+	},
+	{
+		Version: 4,
+		Name:    "create_outbox_events_table",
+		Up: execStatements(
+			`CREATE TABLE IF NOT EXISTS "outbox_events" (
+				"id" uuid,
+				"event_type" text NOT NULL,
+				"aggregate_id" uuid,
+				"payload" jsonb NOT NULL,
+				"status" text NOT NULL DEFAULT 'pending',
+				"attempts" bigint NOT NULL DEFAULT 0,
+				"last_error" text,
+				"next_attempt_at" timestamptz NOT NULL,
+				"sent_at" timestamptz,
+				"created_at" timestamptz,
+				"updated_at" timestamptz,
+				"deleted_at" timestamptz,
+				PRIMARY KEY ("id")
+			)`,
+			`CREATE INDEX IF NOT EXISTS "idx_outbox_events_relay" ON "outbox_events" ("status","next_attempt_at")`,
+			`CREATE INDEX IF NOT EXISTS "idx_outbox_events_aggregate_id" ON "outbox_events" ("aggregate_id")`,
+			`CREATE INDEX IF NOT EXISTS "idx_outbox_events_event_type" ON "outbox_events" ("event_type")`,
+		),
+		Down: execStatements(
+			`DROP TABLE IF EXISTS "outbox_events"`,
+		),
+	},
+	{
+		Version: 5,
+		Name:    "create_backfill_checkpoints_table",
+		Up: execStatements(
+			`CREATE TABLE IF NOT EXISTS "backfill_checkpoints" (
+				"name" text,
+				"last_created_at" timestamptz,
+				"last_session_id" uuid,
+				"processed" bigint NOT NULL DEFAULT 0,
+				"failed" bigint NOT NULL DEFAULT 0,
+				"filter_faculty_id" uuid,
+				"filter_from" timestamptz,
+				"filter_to" timestamptz,
+				"created_at" timestamptz,
+				"updated_at" timestamptz,
+				PRIMARY KEY ("name")
+			)`,
+		),
+		Down: execStatements(
+			`DROP TABLE IF EXISTS "backfill_checkpoints"`,
+		),
+	},
+	{
+		Version: 6,
+		Name:    "create_task_ledgers_table",
+		Up: execStatements(
+			`CREATE TABLE IF NOT EXISTS "task_ledgers" (
+				"id" uuid,
+				"task_type" text NOT NULL,
+				"status" text NOT NULL,
+				"attempts" bigint NOT NULL DEFAULT 0,
+				"last_error" text,
+				"payload" jsonb,
+				"received_at" timestamptz NOT NULL,
+				"started_at" timestamptz,
+				"finished_at" timestamptz,
+				"duration_ms" bigint,
+				"session_id" uuid,
+				"created_at" timestamptz,
+				"updated_at" timestamptz,
+				"deleted_at" timestamptz,
+				PRIMARY KEY ("id")
+			)`,
+			`CREATE INDEX IF NOT EXISTS "idx_task_ledgers_session_id" ON "task_ledgers" ("session_id")`,
+			`CREATE INDEX IF NOT EXISTS "idx_task_ledgers_received_at" ON "task_ledgers" ("received_at")`,
+			`CREATE INDEX IF NOT EXISTS "idx_task_ledgers_status" ON "task_ledgers" ("status")`,
+			`CREATE INDEX IF NOT EXISTS "idx_task_ledgers_task_type" ON "task_ledgers" ("task_type")`,
+		),
+		Down: execStatements(
+			`DROP TABLE IF EXISTS "task_ledgers"`,
+		),
+	},
+	{
+		Version: 7,
+		Name:    "create_token_tables",
+		Up: execStatements(
+			`CREATE TABLE IF NOT EXISTS "refresh_tokens" (
+				"id" uuid,
+				"family_id" uuid NOT NULL,
+				"user_id" text NOT NULL,
+				"expires_at" timestamptz NOT NULL,
+				"used_at" timestamptz,
+				"revoked_at" timestamptz,
+				"created_at" timestamptz,
+				"updated_at" timestamptz,
+				"deleted_at" timestamptz,
+				PRIMARY KEY ("id")
+			)`,
+			`CREATE INDEX IF NOT EXISTS "idx_refresh_tokens_user_id" ON "refresh_tokens" ("user_id")`,
+			`CREATE INDEX IF NOT EXISTS "idx_refresh_tokens_family_id" ON "refresh_tokens" ("family_id")`,
+			`CREATE TABLE IF NOT EXISTS "revoked_tokens" (
+				"id" uuid,
+				"expires_at" timestamptz NOT NULL,
+				"created_at" timestamptz,
+				"updated_at" timestamptz,
+				"deleted_at" timestamptz,
+				PRIMARY KEY ("id")
+			)`,
+			`CREATE INDEX IF NOT EXISTS "idx_revoked_tokens_expires_at" ON "revoked_tokens" ("expires_at")`,
+		),
+		Down: execStatements(
+			`DROP TABLE IF EXISTS "revoked_tokens"`,
+			`DROP TABLE IF EXISTS "refresh_tokens"`,
+		),
+	},
+	{
+		Version: 8,
+		Name:    "create_api_keys_table",
+		Up: execStatements(
+			`CREATE TABLE IF NOT EXISTS "api_keys" (
+				"id" uuid,
+				"name" text NOT NULL,
+				"prefix" text NOT NULL,
+				"key_hash" text NOT NULL,
+				"scopes" text NOT NULL,
+				"expires_at" timestamptz,
+				"last_used_at" timestamptz,
+				"revoked_at" timestamptz,
+				"created_at" timestamptz,
+				"updated_at" timestamptz,
+				"deleted_at" timestamptz,
+				PRIMARY KEY ("id")
+			)`,
+			`CREATE UNIQUE INDEX IF NOT EXISTS "idx_api_keys_key_hash" ON "api_keys" ("key_hash")`,
+		),
+		Down: execStatements(
+			`DROP TABLE IF EXISTS "api_keys"`,
+		),
+	},
+	{
+		Version: 9,
+		Name:    "add_users_locale_column",
+		// IF NOT EXISTS: database yang versi 1-nya dijalankan lewat AutoMigrate sudah punya kolom ini
+		Up: execStatements(
+			`ALTER TABLE "users" ADD COLUMN IF NOT EXISTS "locale" varchar(8) NOT NULL DEFAULT 'id'`,
+		),
+		// kolom tidak di-drop: entity.User mewajibkan locale (not null), sedangkan kode versi
+		// sebelumnya tidak terganggu oleh kolom tambahan yang punya default
+		Down: execStatements(),
+	},
+	{
+		Version: 10,
+		Name:    "add_outbox_events_headers_column",
+		Up: execStatements(
+			`ALTER TABLE "outbox_events" ADD COLUMN IF NOT EXISTS "headers" jsonb`,
+		),
+		Down: execStatements(
+			`ALTER TABLE "outbox_events" DROP COLUMN IF EXISTS "headers"`,
+		),
+	},
+}
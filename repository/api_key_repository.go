@@ -0,0 +1,94 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/Amierza/worker-service/dto"
+	"github.com/Amierza/worker-service/entity"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type (
+	IAPIKeyRepository interface {
+		// CREATE / POST
+		CreateAPIKey(ctx context.Context, tx *gorm.DB, apiKey entity.APIKey) error
+
+		// READ / GET
+		GetAPIKeyByHash(ctx context.Context, tx *gorm.DB, keyHash string) (entity.APIKey, error)
+
+		// UPDATE / PATCH
+		TouchAPIKeyLastUsed(ctx context.Context, tx *gorm.DB, id uuid.UUID, usedAt time.Time, throttle time.Duration) error
+		RevokeAPIKey(ctx context.Context, tx *gorm.DB, id uuid.UUID) error
+
+		// DELETE / DELETE
+	}
+
+	apiKeyRepository struct {
+		db *gorm.DB
+	}
+)
+
+func NewAPIKeyRepository(db *gorm.DB) *apiKeyRepository {
+	return &apiKeyRepository{
+		db: db,
+	}
+}
+
+func (ar *apiKeyRepository) CreateAPIKey(ctx context.Context, tx *gorm.DB, apiKey entity.APIKey) error {
+	if tx == nil {
+		tx = ar.db
+	}
+
+	return tx.WithContext(ctx).Create(&apiKey).Error
+}
+
+func (ar *apiKeyRepository) GetAPIKeyByHash(ctx context.Context, tx *gorm.DB, keyHash string) (entity.APIKey, error) {
+	if tx == nil {
+		tx = ar.db
+	}
+
+	var apiKey entity.APIKey
+	if err := tx.WithContext(ctx).Where("key_hash = ?", keyHash).Take(&apiKey).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return entity.APIKey{}, dto.ErrNotFound
+		}
+		return entity.APIKey{}, err
+	}
+
+	return apiKey, nil
+}
+
+// TouchAPIKeyLastUsed hanya menulis jika last_used_at lebih lama dari throttle agar
+// key yang sering dipakai tidak memicu UPDATE di setiap request.
+func (ar *apiKeyRepository) TouchAPIKeyLastUsed(ctx context.Context, tx *gorm.DB, id uuid.UUID, usedAt time.Time, throttle time.Duration) error {
+	if tx == nil {
+		tx = ar.db
+	}
+
+	return tx.WithContext(ctx).
+		Model(&entity.APIKey{}).
+		Where("id = ? AND (last_used_at IS NULL OR last_used_at < ?)", id, usedAt.Add(-throttle)).
+		Update("last_used_at", usedAt).Error
+}
+
+func (ar *apiKeyRepository) RevokeAPIKey(ctx context.Context, tx *gorm.DB, id uuid.UUID) error {
+	if tx == nil {
+		tx = ar.db
+	}
+
+	result := tx.WithContext(ctx).
+		Model(&entity.APIKey{}).
+		Where("id = ? AND revoked_at IS NULL", id).
+		Update("revoked_at", time.Now())
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return dto.ErrNotFound
+	}
+
+	return nil
+}
@@ -0,0 +1,85 @@
+package repository
+
+import (
+	"context"
+	"errors"
+
+	"github.com/Amierza/worker-service/dto"
+	"github.com/Amierza/worker-service/entity"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type (
+	IAuthorizationRepository interface {
+		// READ / GET
+		GetSessionOwnership(ctx context.Context, tx *gorm.DB, sessionID uuid.UUID) (entity.Session, error)
+		IsThesisExists(ctx context.Context, tx *gorm.DB, thesisID uuid.UUID) (bool, error)
+		IsThesisMember(ctx context.Context, tx *gorm.DB, userID, thesisID uuid.UUID) (bool, error)
+	}
+
+	authorizationRepository struct {
+		db *gorm.DB
+	}
+)
+
+func NewAuthorizationRepository(db *gorm.DB) *authorizationRepository {
+	return &authorizationRepository{
+		db: db,
+	}
+}
+
+// GetSessionOwnership hanya mengambil kolom yang dibutuhkan untuk cek akses.
+func (ar *authorizationRepository) GetSessionOwnership(ctx context.Context, tx *gorm.DB, sessionID uuid.UUID) (entity.Session, error) {
+	if tx == nil {
+		tx = ar.db
+	}
+
+	var session entity.Session
+	if err := tx.WithContext(ctx).
+		Select("id", "thesis_id", "user_id_owner").
+		Where("id = ?", sessionID).
+		Take(&session).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return entity.Session{}, dto.ErrNotFound
+		}
+		return entity.Session{}, err
+	}
+
+	return session, nil
+}
+
+func (ar *authorizationRepository) IsThesisExists(ctx context.Context, tx *gorm.DB, thesisID uuid.UUID) (bool, error) {
+	if tx == nil {
+		tx = ar.db
+	}
+
+	var count int64
+	if err := tx.WithContext(ctx).Model(&entity.Thesis{}).Where("id = ?", thesisID).Count(&count).Error; err != nil {
+		return false, err
+	}
+
+	return count > 0, nil
+}
+
+// IsThesisMember mengecek apakah user adalah mahasiswa pemilik thesis atau salah satu dosen pembimbingnya.
+func (ar *authorizationRepository) IsThesisMember(ctx context.Context, tx *gorm.DB, userID, thesisID uuid.UUID) (bool, error) {
+	if tx == nil {
+		tx = ar.db
+	}
+
+	var count int64
+	if err := tx.WithContext(ctx).
+		Model(&entity.User{}).
+		Where("users.id = ?", userID).
+		Where(
+			`(EXISTS (SELECT 1 FROM theses t WHERE t.id = ? AND t.student_id = users.student_id AND t.deleted_at IS NULL)
+			OR EXISTS (SELECT 1 FROM thesis_supervisors ts WHERE ts.thesis_id = ? AND ts.lecturer_id = users.lecturer_id AND ts.deleted_at IS NULL))`,
+			thesisID, thesisID,
+		).
+		Count(&count).Error; err != nil {
+		return false, err
+	}
+
+	return count > 0, nil
+}
@@ -0,0 +1,110 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/Amierza/worker-service/dto"
+	"github.com/Amierza/worker-service/entity"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type (
+	IBackfillRepository interface {
+		// CREATE / POST
+		SaveBackfillCheckpoint(ctx context.Context, tx *gorm.DB, checkpoint entity.BackfillCheckpoint) error
+
+		// READ / GET
+		GetBackfillCheckpoint(ctx context.Context, tx *gorm.DB, name string) (entity.BackfillCheckpoint, error)
+		GetBackfillCandidates(ctx context.Context, tx *gorm.DB, filter dto.BackfillFilter, after entity.BackfillCheckpoint, limit int) ([]entity.Session, error)
+
+		// UPDATE / PATCH
+
+		// DELETE / DELETE
+	}
+
+	backfillRepository struct {
+		db *gorm.DB
+	}
+)
+
+func NewBackfillRepository(db *gorm.DB) *backfillRepository {
+	return &backfillRepository{
+		db: db,
+	}
+}
+
+func (br *backfillRepository) SaveBackfillCheckpoint(ctx context.Context, tx *gorm.DB, checkpoint entity.BackfillCheckpoint) error {
+	if tx == nil {
+		tx = br.db
+	}
+
+	return tx.WithContext(ctx).
+		Clauses(clause.OnConflict{
+			Columns:   []clause.Column{{Name: "name"}},
+			DoUpdates: clause.AssignmentColumns([]string{"last_created_at", "last_session_id", "processed", "failed", "filter_faculty_id", "filter_from", "filter_to", "updated_at"}),
+		}).
+		Create(&checkpoint).Error
+}
+
+func (br *backfillRepository) GetBackfillCheckpoint(ctx context.Context, tx *gorm.DB, name string) (entity.BackfillCheckpoint, error) {
+	if tx == nil {
+		tx = br.db
+	}
+
+	var checkpoint entity.BackfillCheckpoint
+	if err := tx.WithContext(ctx).Where("name = ?", name).Take(&checkpoint).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return entity.BackfillCheckpoint{}, dto.ErrNotFound
+		}
+		return entity.BackfillCheckpoint{}, err
+	}
+
+	return checkpoint, nil
+}
+
+// GetBackfillCandidates mencari sesi finished (atau processing_summary yang macet) yang belum punya ringkasan.
+// Memakai keyset pagination (created_at, id) setelah posisi checkpoint agar bisa dilanjutkan.
+func (br *backfillRepository) GetBackfillCandidates(ctx context.Context, tx *gorm.DB, filter dto.BackfillFilter, after entity.BackfillCheckpoint, limit int) ([]entity.Session, error) {
+	if tx == nil {
+		tx = br.db
+	}
+
+	query := tx.WithContext(ctx).
+		Model(&entity.Session{}).
+		Where("NOT EXISTS (SELECT 1 FROM summaries WHERE summaries.session_id = sessions.id AND summaries.deleted_at IS NULL)").
+		Where("sessions.status = ? OR (sessions.status = ? AND sessions.updated_at < ?)",
+			entity.FINISHED,
+			entity.PROCESSING_SUMMARY,
+			time.Now().Add(-filter.StuckAfter),
+		)
+
+	if filter.FacultyID != nil {
+		query = query.
+			Joins("JOIN theses ON theses.id = sessions.thesis_id").
+			Joins("JOIN students ON students.id = theses.student_id").
+			Joins("JOIN study_programs ON study_programs.id = students.study_program_id").
+			Where("study_programs.faculty_id = ?", *filter.FacultyID)
+	}
+	if filter.From != nil {
+		query = query.Where("sessions.created_at >= ?", *filter.From)
+	}
+	if filter.To != nil {
+		query = query.Where("sessions.created_at < ?", *filter.To)
+	}
+	if !after.LastCreatedAt.IsZero() {
+		query = query.Where("(sessions.created_at, sessions.id) > (?, ?)", after.LastCreatedAt, after.LastSessionID)
+	}
+
+	var sessions []entity.Session
+	if err := query.
+		Order("sessions.created_at ASC, sessions.id ASC").
+		Limit(limit).
+		Find(&sessions).Error; err != nil {
+		return nil, err
+	}
+
+	return sessions, nil
+}
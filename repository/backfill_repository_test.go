@@ -0,0 +1,188 @@
+package repository
+
+import (
+	"context"
+	"sort"
+	"testing"
+	"time"
+
+	"github.com/Amierza/worker-service/dto"
+	"github.com/Amierza/worker-service/entity"
+	"github.com/Amierza/worker-service/internal/testdb"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type backfillFixture struct {
+	db   *gorm.DB
+	repo *backfillRepository
+	base time.Time
+	// kandidat sesuai urutan keyset (created_at, id)
+	candidates []entity.Session
+}
+
+func newBackfillFixture(t *testing.T) backfillFixture {
+	t.Helper()
+
+	db := testdb.New(t, &entity.Session{}, &entity.Summary{}, &entity.BackfillCheckpoint{})
+
+	// waktu lokal tanpa pecahan detik, sama seperti time.Now() yang dipakai filter stuck
+	base := time.Now().Add(-48 * time.Hour).Truncate(time.Second)
+	stale := time.Now().Add(-2 * time.Hour)
+
+	newSession := func(status entity.SessionStatus, createdAt time.Time) entity.Session {
+		return entity.Session{ID: uuid.New(), Status: status, TimeStamp: entity.TimeStamp{CreatedAt: createdAt, UpdatedAt: stale}}
+	}
+
+	var (
+		first      = newSession(entity.FINISHED, base)
+		tieA       = newSession(entity.FINISHED, base.Add(time.Minute))
+		tieB       = newSession(entity.FINISHED, base.Add(time.Minute))
+		summarized = newSession(entity.FINISHED, base.Add(2*time.Minute))
+		stuck      = newSession(entity.PROCESSING_SUMMARY, base.Add(3*time.Minute))
+		processing = newSession(entity.PROCESSING_SUMMARY, base.Add(4*time.Minute))
+		ongoing    = newSession(entity.ONGOING, base.Add(5*time.Minute))
+	)
+	// masih diproses worker (belum melewati stuck-after)
+	processing.UpdatedAt = time.Now()
+
+	for _, session := range []*entity.Session{&first, &tieA, &tieB, &summarized, &stuck, &processing, &ongoing} {
+		if err := db.Create(session).Error; err != nil {
+			t.Fatalf("failed to seed session: %v", err)
+		}
+	}
+	if err := db.Create(&entity.Summary{ID: uuid.New(), SessionID: summarized.ID, Version: 1, Content: []byte(`{}`)}).Error; err != nil {
+		t.Fatalf("failed to seed summary: %v", err)
+	}
+
+	// sesi dengan created_at sama diurutkan berdasarkan id
+	ties := []entity.Session{tieA, tieB}
+	sort.Slice(ties, func(i, j int) bool { return ties[i].ID.String() < ties[j].ID.String() })
+
+	return backfillFixture{
+		db:         db,
+		repo:       NewBackfillRepository(db),
+		base:       base,
+		candidates: []entity.Session{first, ties[0], ties[1], stuck},
+	}
+}
+
+func TestGetBackfillCandidatesKeyset(t *testing.T) {
+	f := newBackfillFixture(t)
+	filter := dto.BackfillFilter{StuckAfter: time.Hour}
+
+	after := func(s entity.Session) entity.BackfillCheckpoint {
+		return entity.BackfillCheckpoint{LastCreatedAt: s.CreatedAt, LastSessionID: s.ID}
+	}
+
+	tests := []struct {
+		name  string
+		after entity.BackfillCheckpoint
+		limit int
+		want  []entity.Session
+	}{
+		{name: "no checkpoint", limit: 10, want: f.candidates},
+		{name: "limit", limit: 2, want: f.candidates[:2]},
+		{name: "after first", after: after(f.candidates[0]), limit: 10, want: f.candidates[1:]},
+		// created_at sama dengan checkpoint tetap diambil jika id lebih besar
+		{name: "between same created_at", after: after(f.candidates[1]), limit: 10, want: f.candidates[2:]},
+		{name: "after last", after: after(f.candidates[3]), limit: 10, want: nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := f.repo.GetBackfillCandidates(context.Background(), nil, filter, tt.after, tt.limit)
+			if err != nil {
+				t.Fatalf("GetBackfillCandidates: %v", err)
+			}
+			assertSessionIDs(t, got, tt.want)
+		})
+	}
+}
+
+func TestGetBackfillCandidatesResumeVisitsEverySessionOnce(t *testing.T) {
+	f := newBackfillFixture(t)
+	ctx := context.Background()
+	filter := dto.BackfillFilter{StuckAfter: time.Hour}
+
+	var visited []entity.Session
+	for page := 0; ; page++ {
+		if page > len(f.candidates) {
+			t.Fatal("pagination did not terminate")
+		}
+
+		// checkpoint disimpan & dibaca ulang setiap halaman, seperti backfill yang di-restart
+		checkpoint, err := f.repo.GetBackfillCheckpoint(ctx, nil, "resume")
+		if err != nil && page > 0 {
+			t.Fatalf("GetBackfillCheckpoint: %v", err)
+		}
+
+		sessions, err := f.repo.GetBackfillCandidates(ctx, nil, filter, checkpoint, 2)
+		if err != nil {
+			t.Fatalf("GetBackfillCandidates: %v", err)
+		}
+		if len(sessions) == 0 {
+			break
+		}
+		visited = append(visited, sessions...)
+
+		last := sessions[len(sessions)-1]
+		if err := f.repo.SaveBackfillCheckpoint(ctx, nil, entity.BackfillCheckpoint{
+			Name:          "resume",
+			LastCreatedAt: last.CreatedAt,
+			LastSessionID: last.ID,
+			Processed:     len(visited),
+		}); err != nil {
+			t.Fatalf("SaveBackfillCheckpoint: %v", err)
+		}
+	}
+
+	assertSessionIDs(t, visited, f.candidates)
+
+	saved, err := f.repo.GetBackfillCheckpoint(ctx, nil, "resume")
+	if err != nil {
+		t.Fatalf("GetBackfillCheckpoint: %v", err)
+	}
+	if saved.Processed != len(f.candidates) {
+		t.Errorf("checkpoint processed = %d, want %d (upsert must overwrite)", saved.Processed, len(f.candidates))
+	}
+}
+
+func TestGetBackfillCandidatesFilter(t *testing.T) {
+	f := newBackfillFixture(t)
+
+	from, to := f.base.Add(time.Minute), f.base.Add(3*time.Minute)
+	tests := []struct {
+		name   string
+		filter dto.BackfillFilter
+		want   []entity.Session
+	}{
+		{name: "from inclusive", filter: dto.BackfillFilter{StuckAfter: time.Hour, From: &from}, want: f.candidates[1:]},
+		{name: "to exclusive", filter: dto.BackfillFilter{StuckAfter: time.Hour, To: &to}, want: f.candidates[:3]},
+		// stuck-after lebih lama dari umur sesi processing_summary: belum dianggap macet
+		{name: "stuck after", filter: dto.BackfillFilter{StuckAfter: 3 * time.Hour}, want: f.candidates[:3]},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := f.repo.GetBackfillCandidates(context.Background(), nil, tt.filter, entity.BackfillCheckpoint{}, 10)
+			if err != nil {
+				t.Fatalf("GetBackfillCandidates: %v", err)
+			}
+			assertSessionIDs(t, got, tt.want)
+		})
+	}
+}
+
+func assertSessionIDs(t *testing.T, got, want []entity.Session) {
+	t.Helper()
+
+	if len(got) != len(want) {
+		t.Fatalf("got %d sessions, want %d", len(got), len(want))
+	}
+	for i := range want {
+		if got[i].ID != want[i].ID {
+			t.Errorf("session[%d] = %s, want %s", i, got[i].ID, want[i].ID)
+		}
+	}
+}
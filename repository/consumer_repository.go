@@ -2,20 +2,39 @@ package repository
 
 import (
 	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
 
 	"github.com/Amierza/worker-service/dto"
 	"github.com/Amierza/worker-service/entity"
+	"github.com/Amierza/worker-service/helper"
+	"github.com/google/uuid"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
+// jumlah baris per statement INSERT agar tidak melewati batas parameter postgres
+const messageUpsertBatchSize = 500
+
 type (
 	IConsumerRepository interface {
 		// CREATE / POST
 		SaveMessages(ctx context.Context, tx *gorm.DB, task dto.TaskSummary) error
+		SaveSummary(ctx context.Context, tx *gorm.DB, summary *entity.Summary) error
+		CreateNotifications(ctx context.Context, tx *gorm.DB, notifications []entity.Notification) error
 
 		// READ / GET
+		GetSessionByID(ctx context.Context, tx *gorm.DB, sessionID uuid.UUID) (entity.Session, error)
+		GetThesisByID(ctx context.Context, tx *gorm.DB, thesisID uuid.UUID) (entity.Thesis, error)
+		GetThesisSupervisorsByThesisID(ctx context.Context, tx *gorm.DB, thesisID uuid.UUID) ([]entity.ThesisSupervisor, error)
+		GetMessagesBySessionID(ctx context.Context, tx *gorm.DB, sessionID uuid.UUID) ([]entity.Message, error)
+		GetTaskSummaryBySessionID(ctx context.Context, tx *gorm.DB, sessionID uuid.UUID) (dto.TaskSummary, error)
+		GetNotificationRecipients(ctx context.Context, tx *gorm.DB, ownerID, studentID uuid.UUID, lecturerIDs []uuid.UUID) ([]entity.User, error)
 
 		// UPDATE / PATCH
+		UpdateSessionStatus(ctx context.Context, tx *gorm.DB, sessionID uuid.UUID, from []entity.SessionStatus, to entity.SessionStatus) error
 
 		// DELETE / DELETE
 	}
@@ -36,8 +55,19 @@ func (cr *consumerRepository) SaveMessages(ctx context.Context, tx *gorm.DB, tas
 		tx = cr.db
 	}
 
-	var messages []entity.Message
+	loc := helper.DefaultLocation()
+
+	var (
+		messages   []entity.Message
+		invalidIDs []string
+	)
 	for _, m := range task.Messages {
+		sentAt, err := helper.ParseTimestamp(m.Timestamp, loc)
+		if err != nil {
+			invalidIDs = append(invalidIDs, m.ID.String())
+			continue
+		}
+
 		messages = append(messages, entity.Message{
 			ID:              m.ID,
 			IsText:          m.IsText,
@@ -48,7 +78,314 @@ func (cr *consumerRepository) SaveMessages(ctx context.Context, tx *gorm.DB, tas
 			SenderID:        m.Sender.ID,
 			SessionID:       task.SessionID,
 			ParentMessageID: m.ParentMessageID,
+			SentAt:          sentAt,
+			TimeStamp: entity.TimeStamp{
+				CreatedAt: sentAt,
+			},
 		})
 	}
-	return tx.WithContext(ctx).Save(&messages).Error
+
+	// jangan simpan sebagian pesan dengan waktu yang salah
+	if len(invalidIDs) > 0 {
+		return fmt.Errorf("%w: %s", dto.ErrInvalidMessageTimestamp, strings.Join(invalidIDs, ", "))
+	}
+
+	if len(messages) == 0 {
+		return nil
+	}
+
+	// Upsert: pesan baru di-insert, pesan lama hanya di-update jika isinya berubah.
+	// created_at & sent_at tidak ikut di-update sehingga waktu asli tetap terjaga.
+	upsert := clause.OnConflict{
+		Columns: []clause.Column{{Name: "id"}},
+		DoUpdates: clause.Assignments(map[string]any{
+			"is_text":           gorm.Expr("excluded.is_text"),
+			"text":              gorm.Expr("excluded.text"),
+			"file_url":          gorm.Expr("excluded.file_url"),
+			"file_type":         gorm.Expr("excluded.file_type"),
+			"parent_message_id": gorm.Expr("excluded.parent_message_id"),
+			"is_edited":         true,
+			"edited_at":         gorm.Expr("excluded.updated_at"),
+			"updated_at":        gorm.Expr("excluded.updated_at"),
+		}),
+		Where: clause.Where{Exprs: []clause.Expression{
+			gorm.Expr(`messages.is_text IS DISTINCT FROM excluded.is_text
+				OR messages.text IS DISTINCT FROM excluded.text
+				OR messages.file_url IS DISTINCT FROM excluded.file_url
+				OR messages.file_type IS DISTINCT FROM excluded.file_type
+				OR messages.parent_message_id IS DISTINCT FROM excluded.parent_message_id`),
+		}},
+	}
+
+	return tx.WithContext(ctx).Clauses(upsert).CreateInBatches(&messages, messageUpsertBatchSize).Error
+}
+
+func (cr *consumerRepository) SaveSummary(ctx context.Context, tx *gorm.DB, summary *entity.Summary) error {
+	if tx == nil {
+		tx = cr.db
+	}
+
+	// versi baru = versi terakhir + 1, unique index (session_id, version) menjaga dari race
+	var lastVersion int
+	if err := tx.WithContext(ctx).
+		Model(&entity.Summary{}).
+		Where("session_id = ?", summary.SessionID).
+		Select("COALESCE(MAX(version), 0)").
+		Scan(&lastVersion).Error; err != nil {
+		return err
+	}
+
+	if summary.ID == uuid.Nil {
+		summary.ID = uuid.New()
+	}
+	summary.Version = lastVersion + 1
+
+	return tx.WithContext(ctx).Create(summary).Error
+}
+
+func (cr *consumerRepository) CreateNotifications(ctx context.Context, tx *gorm.DB, notifications []entity.Notification) error {
+	if tx == nil {
+		tx = cr.db
+	}
+
+	if len(notifications) == 0 {
+		return nil
+	}
+
+	return tx.WithContext(ctx).Create(&notifications).Error
+}
+
+func (cr *consumerRepository) GetSessionByID(ctx context.Context, tx *gorm.DB, sessionID uuid.UUID) (entity.Session, error) {
+	if tx == nil {
+		tx = cr.db
+	}
+
+	var session entity.Session
+	if err := tx.WithContext(ctx).
+		Joins("UserOwner").
+		Joins("UserOwner.Student").
+		Joins("UserOwner.Lecturer").
+		Where("sessions.id = ?", sessionID).
+		Take(&session).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return entity.Session{}, dto.ErrNotFound
+		}
+		return entity.Session{}, err
+	}
+
+	return session, nil
+}
+
+func (cr *consumerRepository) GetThesisByID(ctx context.Context, tx *gorm.DB, thesisID uuid.UUID) (entity.Thesis, error) {
+	if tx == nil {
+		tx = cr.db
+	}
+
+	var thesis entity.Thesis
+	if err := tx.WithContext(ctx).
+		Joins("Student").
+		Joins("Student.StudyProgram").
+		Joins("Student.StudyProgram.Faculty").
+		Where("theses.id = ?", thesisID).
+		Take(&thesis).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return entity.Thesis{}, dto.ErrNotFound
+		}
+		return entity.Thesis{}, err
+	}
+
+	return thesis, nil
+}
+
+func (cr *consumerRepository) GetThesisSupervisorsByThesisID(ctx context.Context, tx *gorm.DB, thesisID uuid.UUID) ([]entity.ThesisSupervisor, error) {
+	if tx == nil {
+		tx = cr.db
+	}
+
+	var supervisors []entity.ThesisSupervisor
+	if err := tx.WithContext(ctx).
+		Joins("Lecturer").
+		Joins("Lecturer.StudyProgram").
+		Joins("Lecturer.StudyProgram.Faculty").
+		Where("thesis_supervisors.thesis_id = ?", thesisID).
+		Order("thesis_supervisors.role ASC").
+		Find(&supervisors).Error; err != nil {
+		return nil, err
+	}
+
+	return supervisors, nil
+}
+
+func (cr *consumerRepository) GetMessagesBySessionID(ctx context.Context, tx *gorm.DB, sessionID uuid.UUID) ([]entity.Message, error) {
+	if tx == nil {
+		tx = cr.db
+	}
+
+	var messages []entity.Message
+	if err := tx.WithContext(ctx).
+		Joins("Sender").
+		Joins("Sender.Student").
+		Joins("Sender.Lecturer").
+		Where("messages.session_id = ?", sessionID).
+		Order("messages.sent_at ASC, messages.created_at ASC, messages.id ASC").
+		Find(&messages).Error; err != nil {
+		return nil, err
+	}
+
+	return messages, nil
+}
+
+// GetTaskSummaryBySessionID menyusun ulang dto.TaskSummary dari database,
+// dipakai untuk regenerate, audit maupun backfill ringkasan.
+func (cr *consumerRepository) GetTaskSummaryBySessionID(ctx context.Context, tx *gorm.DB, sessionID uuid.UUID) (dto.TaskSummary, error) {
+	session, err := cr.GetSessionByID(ctx, tx, sessionID)
+	if err != nil {
+		return dto.TaskSummary{}, err
+	}
+
+	thesis, err := cr.GetThesisByID(ctx, tx, session.ThesisID)
+	if err != nil {
+		return dto.TaskSummary{}, err
+	}
+
+	supervisors, err := cr.GetThesisSupervisorsByThesisID(ctx, tx, session.ThesisID)
+	if err != nil {
+		return dto.TaskSummary{}, err
+	}
+
+	messages, err := cr.GetMessagesBySessionID(ctx, tx, sessionID)
+	if err != nil {
+		return dto.TaskSummary{}, err
+	}
+
+	task := dto.TaskSummary{
+		SessionID:     session.ID,
+		SessionStatus: string(session.Status),
+		StartedAt:     session.StartTime,
+		EndedAt:       session.EndTime,
+		CreatedAt:     session.CreatedAt,
+		Owner:         toCustomUserResponse(session.UserOwner),
+		Student: dto.StudentResponse{
+			ID:           thesis.Student.ID,
+			Nim:          thesis.Student.Nim,
+			Name:         thesis.Student.Name,
+			Email:        thesis.Student.Email,
+			StudyProgram: toStudyProgramResponse(thesis.Student.StudyProgram),
+		},
+		ThesisInfo: dto.ThesisSummary{
+			Title:       thesis.Title,
+			Description: thesis.Description,
+			Progress:    thesis.Progress,
+		},
+	}
+
+	for _, sup := range supervisors {
+		task.Supervisors = append(task.Supervisors, dto.LecturerResponse{
+			ID:           sup.Lecturer.ID,
+			Nip:          sup.Lecturer.Nip,
+			Name:         sup.Lecturer.Name,
+			Email:        sup.Lecturer.Email,
+			TotalStudent: sup.Lecturer.TotalStudent,
+			Role:         string(sup.Role),
+			StudyProgram: toStudyProgramResponse(sup.Lecturer.StudyProgram),
+		})
+	}
+
+	for _, m := range messages {
+		sentAt := m.SentAt
+		if sentAt.IsZero() {
+			sentAt = m.CreatedAt
+		}
+
+		task.Messages = append(task.Messages, dto.MessageSummary{
+			ID:              m.ID,
+			IsText:          m.IsText,
+			Text:            m.Text,
+			FileURL:         m.FileURL,
+			FileType:        m.FileType,
+			Sender:          toCustomUserResponse(m.Sender),
+			ParentMessageID: m.ParentMessageID,
+			Timestamp:       sentAt.Format(time.RFC3339Nano),
+		})
+	}
+
+	return task, nil
+}
+
+func toCustomUserResponse(user entity.User) dto.CustomUserResponse {
+	name := user.Student.Name
+	if user.LecturerID != nil {
+		name = user.Lecturer.Name
+	}
+
+	return dto.CustomUserResponse{
+		ID:         user.ID,
+		Name:       name,
+		Identifier: user.Identifier,
+		Role:       string(user.Role),
+	}
+}
+
+func toStudyProgramResponse(sp entity.StudyProgram) dto.StudyProgramResponse {
+	return dto.StudyProgramResponse{
+		ID:     sp.ID,
+		Name:   sp.Name,
+		Degree: sp.Degree,
+		Faculty: dto.FacultyResponse{
+			ID:   sp.Faculty.ID,
+			Name: sp.Faculty.Name,
+		},
+	}
+}
+
+// GetNotificationRecipients mengambil owner sesi, user mahasiswa & user dosen pembimbing beserta
+// locale-nya untuk menerjemahkan notifikasi.
+func (cr *consumerRepository) GetNotificationRecipients(ctx context.Context, tx *gorm.DB, ownerID, studentID uuid.UUID, lecturerIDs []uuid.UUID) ([]entity.User, error) {
+	if tx == nil {
+		tx = cr.db
+	}
+
+	query := tx.WithContext(ctx).
+		Model(&entity.User{}).
+		Select("id", "locale").
+		Where("id = ?", ownerID).
+		Or("student_id = ?", studentID)
+	if len(lecturerIDs) > 0 {
+		query = query.Or("lecturer_id IN ?", lecturerIDs)
+	}
+
+	var users []entity.User
+	if err := query.Find(&users).Error; err != nil {
+		return nil, err
+	}
+
+	return users, nil
+}
+
+func (cr *consumerRepository) UpdateSessionStatus(ctx context.Context, tx *gorm.DB, sessionID uuid.UUID, from []entity.SessionStatus, to entity.SessionStatus) error {
+	if tx == nil {
+		tx = cr.db
+	}
+
+	result := tx.WithContext(ctx).
+		Model(&entity.Session{}).
+		Where("id = ? AND status IN ?", sessionID, from).
+		Update("status", to)
+	if result.Error != nil {
+		return result.Error
+	}
+
+	if result.RowsAffected == 0 {
+		// bedakan sesi yang tidak ada / sudah dihapus dari sesi yang statusnya tidak sesuai
+		var count int64
+		if err := tx.WithContext(ctx).Model(&entity.Session{}).Where("id = ?", sessionID).Count(&count).Error; err != nil {
+			return err
+		}
+		if count == 0 {
+			return fmt.Errorf("%w: session %s", dto.ErrNotFound, sessionID)
+		}
+		return fmt.Errorf("%w: session %s", dto.ErrInvalidSessionStatusTransition, sessionID)
+	}
+
+	return nil
 }
@@ -0,0 +1,525 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/Amierza/worker-service/dto"
+	"github.com/Amierza/worker-service/entity"
+	"github.com/Amierza/worker-service/internal/testdb"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+func TestSaveMessagesEditDetection(t *testing.T) {
+	var (
+		sessionID = uuid.New()
+		senderID  = uuid.New()
+		parentID  = uuid.New()
+		messageID = uuid.New()
+	)
+
+	original := dto.MessageSummary{
+		ID:        messageID,
+		IsText:    true,
+		Text:      "bab 2 sudah saya revisi",
+		Sender:    dto.CustomUserResponse{ID: senderID, Role: string(entity.STUDENT)},
+		Timestamp: "2024-03-05T14:30:15+07:00",
+	}
+
+	tests := []struct {
+		name       string
+		edit       func(m *dto.MessageSummary)
+		wantEdited bool
+		check      func(t *testing.T, got entity.Message)
+	}{
+		{
+			name:       "unchanged",
+			edit:       func(m *dto.MessageSummary) {},
+			wantEdited: false,
+		},
+		{
+			name:       "unchanged with different timestamp keeps sent_at",
+			edit:       func(m *dto.MessageSummary) { m.Timestamp = "2024-03-06T09:00:00+07:00" },
+			wantEdited: false,
+		},
+		{
+			name:       "text changed",
+			edit:       func(m *dto.MessageSummary) { m.Text = "bab 2 dan bab 3 sudah saya revisi" },
+			wantEdited: true,
+			check: func(t *testing.T, got entity.Message) {
+				if got.Text != "bab 2 dan bab 3 sudah saya revisi" {
+					t.Errorf("text = %q, want updated text", got.Text)
+				}
+			},
+		},
+		{
+			name: "switched to file",
+			edit: func(m *dto.MessageSummary) {
+				m.IsText = false
+				m.FileURL = "https://files.example/bab2.pdf"
+				m.FileType = "application/pdf"
+			},
+			wantEdited: true,
+			check: func(t *testing.T, got entity.Message) {
+				if got.IsText || got.FileURL == "" || got.FileType == "" {
+					t.Errorf("file columns not updated: %+v", got)
+				}
+			},
+		},
+		{
+			// NULL -> nilai harus terdeteksi (IS DISTINCT FROM, bukan <>)
+			name:       "parent set from null",
+			edit:       func(m *dto.MessageSummary) { m.ParentMessageID = &parentID },
+			wantEdited: true,
+			check: func(t *testing.T, got entity.Message) {
+				if got.ParentMessageID == nil || *got.ParentMessageID != parentID {
+					t.Errorf("parent_message_id = %v, want %s", got.ParentMessageID, parentID)
+				}
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			db := testdb.New(t, &entity.Message{})
+			repo := NewConsumerRepository(db)
+
+			if err := repo.SaveMessages(ctx, nil, dto.TaskSummary{SessionID: sessionID, Messages: []dto.MessageSummary{original}}); err != nil {
+				t.Fatalf("first SaveMessages: %v", err)
+			}
+			before := getMessage(t, db, messageID)
+			if before.IsEdited || before.EditedAt != nil {
+				t.Fatalf("new message marked as edited: %+v", before)
+			}
+
+			edited := original
+			tt.edit(&edited)
+			if err := repo.SaveMessages(ctx, nil, dto.TaskSummary{SessionID: sessionID, Messages: []dto.MessageSummary{edited}}); err != nil {
+				t.Fatalf("second SaveMessages: %v", err)
+			}
+
+			got := getMessage(t, db, messageID)
+			if got.IsEdited != tt.wantEdited {
+				t.Errorf("is_edited = %v, want %v", got.IsEdited, tt.wantEdited)
+			}
+			if tt.wantEdited && got.EditedAt == nil {
+				t.Error("edited_at not set for edited message")
+			}
+			if !tt.wantEdited && got.EditedAt != nil {
+				t.Errorf("edited_at = %v, want nil for unchanged message", got.EditedAt)
+			}
+			if !got.SentAt.Equal(before.SentAt) {
+				t.Errorf("sent_at changed from %s to %s", before.SentAt, got.SentAt)
+			}
+			if !got.CreatedAt.Equal(before.CreatedAt) {
+				t.Errorf("created_at changed from %s to %s", before.CreatedAt, got.CreatedAt)
+			}
+			if tt.check != nil {
+				tt.check(t, got)
+			}
+		})
+	}
+}
+
+func TestSaveMessagesMixedBatch(t *testing.T) {
+	ctx := context.Background()
+	db := testdb.New(t, &entity.Message{})
+	repo := NewConsumerRepository(db)
+
+	sessionID, senderID := uuid.New(), uuid.New()
+	newMessage := func(text string) dto.MessageSummary {
+		return dto.MessageSummary{
+			ID:        uuid.New(),
+			IsText:    true,
+			Text:      text,
+			Sender:    dto.CustomUserResponse{ID: senderID, Role: string(entity.LECTURER)},
+			Timestamp: "2024-03-05 14:30:15",
+		}
+	}
+
+	unchanged, edited := newMessage("silakan lanjut bab 3"), newMessage("revisi abstrak")
+	if err := repo.SaveMessages(ctx, nil, dto.TaskSummary{SessionID: sessionID, Messages: []dto.MessageSummary{unchanged, edited}}); err != nil {
+		t.Fatalf("first SaveMessages: %v", err)
+	}
+
+	edited.Text = "revisi abstrak dan latar belakang"
+	added := newMessage("jadwal bimbingan berikutnya kamis")
+	if err := repo.SaveMessages(ctx, nil, dto.TaskSummary{SessionID: sessionID, Messages: []dto.MessageSummary{unchanged, edited, added}}); err != nil {
+		t.Fatalf("second SaveMessages: %v", err)
+	}
+
+	tests := []struct {
+		name       string
+		id         uuid.UUID
+		wantText   string
+		wantEdited bool
+	}{
+		{name: "unchanged", id: unchanged.ID, wantText: unchanged.Text, wantEdited: false},
+		{name: "edited", id: edited.ID, wantText: edited.Text, wantEdited: true},
+		{name: "inserted", id: added.ID, wantText: added.Text, wantEdited: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := getMessage(t, db, tt.id)
+			if got.Text != tt.wantText {
+				t.Errorf("text = %q, want %q", got.Text, tt.wantText)
+			}
+			if got.IsEdited != tt.wantEdited {
+				t.Errorf("is_edited = %v, want %v", got.IsEdited, tt.wantEdited)
+			}
+		})
+	}
+}
+
+func TestSaveMessagesBatchSize(t *testing.T) {
+	tests := []struct {
+		count          int
+		wantStatements int
+	}{
+		{count: 1, wantStatements: 1},
+		{count: messageUpsertBatchSize, wantStatements: 1},
+		{count: messageUpsertBatchSize + 1, wantStatements: 2},
+		{count: 2*messageUpsertBatchSize + 200, wantStatements: 3},
+	}
+
+	for _, tt := range tests {
+		t.Run(fmt.Sprintf("%d messages", tt.count), func(t *testing.T) {
+			ctx := context.Background()
+			db := testdb.New(t, &entity.Message{})
+			repo := NewConsumerRepository(db)
+
+			var statements int
+			if err := db.Callback().Create().After("gorm:create").Register("test:count_insert", func(tx *gorm.DB) {
+				statements++
+			}); err != nil {
+				t.Fatalf("failed to register callback: %v", err)
+			}
+
+			task := dto.TaskSummary{SessionID: uuid.New()}
+			base := time.Date(2024, 3, 5, 7, 0, 0, 0, time.UTC)
+			for i := 0; i < tt.count; i++ {
+				task.Messages = append(task.Messages, dto.MessageSummary{
+					ID:        uuid.New(),
+					IsText:    true,
+					Text:      fmt.Sprintf("pesan %d", i),
+					Sender:    dto.CustomUserResponse{ID: uuid.New(), Role: string(entity.STUDENT)},
+					Timestamp: base.Add(time.Duration(i) * time.Second).Format(time.RFC3339),
+				})
+			}
+
+			if err := repo.SaveMessages(ctx, nil, task); err != nil {
+				t.Fatalf("SaveMessages: %v", err)
+			}
+
+			if statements != tt.wantStatements {
+				t.Errorf("insert statements = %d, want %d", statements, tt.wantStatements)
+			}
+
+			var stored int64
+			if err := db.Model(&entity.Message{}).Count(&stored).Error; err != nil {
+				t.Fatalf("count messages: %v", err)
+			}
+			if stored != int64(tt.count) {
+				t.Errorf("stored messages = %d, want %d", stored, tt.count)
+			}
+		})
+	}
+}
+
+func TestSaveMessagesRejectsInvalidTimestamp(t *testing.T) {
+	ctx := context.Background()
+	db := testdb.New(t, &entity.Message{})
+	repo := NewConsumerRepository(db)
+
+	task := dto.TaskSummary{
+		SessionID: uuid.New(),
+		Messages: []dto.MessageSummary{
+			{ID: uuid.New(), IsText: true, Text: "ok", Timestamp: "2024-03-05T14:30:15Z"},
+			{ID: uuid.New(), IsText: true, Text: "rusak", Timestamp: "kemarin"},
+		},
+	}
+
+	err := repo.SaveMessages(ctx, nil, task)
+	if !errors.Is(err, dto.ErrInvalidMessageTimestamp) {
+		t.Fatalf("SaveMessages error = %v, want ErrInvalidMessageTimestamp", err)
+	}
+
+	var stored int64
+	if err := db.Model(&entity.Message{}).Count(&stored).Error; err != nil {
+		t.Fatalf("count messages: %v", err)
+	}
+	if stored != 0 {
+		t.Errorf("stored messages = %d, want 0 when a timestamp is invalid", stored)
+	}
+}
+
+func getMessage(t *testing.T, db *gorm.DB, id uuid.UUID) entity.Message {
+	t.Helper()
+
+	var m entity.Message
+	if err := db.First(&m, "id = ?", id).Error; err != nil {
+		t.Fatalf("failed to load message %s: %v", id, err)
+	}
+
+	return m
+}
+
+// readModelFixture berisi satu sesi lengkap (fakultas, prodi, mahasiswa, thesis, dua pembimbing,
+// owner dosen & pesan) untuk menguji query yang menyusun ulang dto.TaskSummary.
+type readModelFixture struct {
+	repo      *consumerRepository
+	faculty   entity.Faculty
+	program   entity.StudyProgram
+	student   entity.Student
+	primary   entity.Lecturer
+	secondary entity.Lecturer
+	thesis    entity.Thesis
+	owner     entity.User
+	studentU  entity.User
+	session   entity.Session
+	// pesan sesuai urutan yang diharapkan (sent_at, created_at, id)
+	messages []entity.Message
+}
+
+func newReadModelFixture(t *testing.T) readModelFixture {
+	t.Helper()
+
+	db := testdb.New(t,
+		&entity.Faculty{}, &entity.StudyProgram{}, &entity.Student{}, &entity.Lecturer{},
+		&entity.User{}, &entity.Thesis{}, &entity.ThesisSupervisor{}, &entity.Session{}, &entity.Message{},
+	)
+
+	f := readModelFixture{repo: NewConsumerRepository(db)}
+	f.faculty = entity.Faculty{ID: uuid.New(), Name: "Teknologi Elektro dan Informatika Cerdas"}
+	f.program = entity.StudyProgram{ID: uuid.New(), Name: "Teknik Informatika", Degree: entity.S1, FacultyID: f.faculty.ID}
+	f.student = entity.Student{ID: uuid.New(), Nim: "5025201001", Name: "Budi Santoso", Email: "budi@student.example", StudyProgramID: f.program.ID}
+	f.primary = entity.Lecturer{ID: uuid.New(), Nip: "198501012010011001", Name: "Dr. Siti Aminah", Email: "siti@example", TotalStudent: 8, StudyProgramID: f.program.ID}
+	f.secondary = entity.Lecturer{ID: uuid.New(), Nip: "199002022015021002", Name: "Andi Wijaya, M.Kom.", Email: "andi@example", TotalStudent: 3, StudyProgramID: f.program.ID}
+	f.thesis = entity.Thesis{ID: uuid.New(), Title: "Deteksi Plagiarisme Skripsi", Description: "perbandingan embedding", Progress: entity.BAB2, StudentID: f.student.ID}
+	f.owner = entity.User{ID: uuid.New(), Identifier: f.primary.Nip, Role: entity.LECTURER, LecturerID: &f.primary.ID}
+	f.studentU = entity.User{ID: uuid.New(), Identifier: f.student.Nim, Role: entity.STUDENT, StudentID: &f.student.ID}
+
+	startedAt := time.Date(2024, 3, 5, 7, 0, 0, 0, time.UTC)
+	endedAt := startedAt.Add(time.Hour)
+	f.session = entity.Session{
+		ID: uuid.New(), Status: entity.FINISHED, StartTime: &startedAt, EndTime: &endedAt,
+		ThesisID: f.thesis.ID, UserIDOwner: f.owner.ID,
+		TimeStamp: entity.TimeStamp{CreatedAt: startedAt.Add(-time.Hour)},
+	}
+
+	// pembimbing kedua disimpan lebih dulu, query harus mengurutkan berdasarkan role
+	supervisors := []entity.ThesisSupervisor{
+		{ID: uuid.New(), Role: entity.SECONDARY_LECTURER, ThesisID: f.thesis.ID, LecturerID: f.secondary.ID},
+		{ID: uuid.New(), Role: entity.PRIMARY_LECTURER, ThesisID: f.thesis.ID, LecturerID: f.primary.ID},
+	}
+
+	newMessage := func(sender entity.User, text string, sentAt time.Time) entity.Message {
+		return entity.Message{
+			ID: uuid.New(), IsText: true, Text: text, SenderRole: sender.Role, SenderID: sender.ID,
+			SessionID: f.session.ID, SentAt: sentAt, TimeStamp: entity.TimeStamp{CreatedAt: startedAt},
+		}
+	}
+	var (
+		first  = newMessage(f.studentU, "bab 2 sudah saya kirim", startedAt.Add(time.Minute))
+		second = newMessage(f.owner, "baik, saya cek", startedAt.Add(2*time.Minute))
+		third  = newMessage(f.studentU, "terima kasih bu", startedAt.Add(3*time.Minute))
+		// pesan sesi lain tidak boleh ikut
+		other = newMessage(f.studentU, "sesi lain", startedAt)
+	)
+	other.SessionID = uuid.New()
+	f.messages = []entity.Message{first, second, third}
+
+	rows := []any{&f.faculty, &f.program, &f.student, &f.primary, &f.secondary, &f.thesis, &f.owner, &f.studentU, &f.session, &supervisors}
+	// disimpan tidak berurutan, urutan hasil ditentukan sent_at
+	for _, m := range []entity.Message{third, other, first, second} {
+		rows = append(rows, &m)
+	}
+	for _, row := range rows {
+		if err := db.Create(row).Error; err != nil {
+			t.Fatalf("failed to seed %T: %v", row, err)
+		}
+	}
+
+	return f
+}
+
+func TestGetSessionByID(t *testing.T) {
+	f := newReadModelFixture(t)
+	ctx := context.Background()
+
+	session, err := f.repo.GetSessionByID(ctx, nil, f.session.ID)
+	if err != nil {
+		t.Fatalf("GetSessionByID() error = %v", err)
+	}
+	if session.Status != entity.FINISHED || session.ThesisID != f.thesis.ID {
+		t.Errorf("session = status %q thesis %s, want %q %s", session.Status, session.ThesisID, entity.FINISHED, f.thesis.ID)
+	}
+	if session.UserOwner.ID != f.owner.ID || session.UserOwner.Lecturer.Name != f.primary.Name {
+		t.Errorf("owner = %s (%q), want %s with joined lecturer %q", session.UserOwner.ID, session.UserOwner.Lecturer.Name, f.owner.ID, f.primary.Name)
+	}
+
+	if _, err := f.repo.GetSessionByID(ctx, nil, uuid.New()); !errors.Is(err, dto.ErrNotFound) {
+		t.Errorf("GetSessionByID(unknown) error = %v, want ErrNotFound", err)
+	}
+}
+
+func TestGetThesisByID(t *testing.T) {
+	f := newReadModelFixture(t)
+	ctx := context.Background()
+
+	thesis, err := f.repo.GetThesisByID(ctx, nil, f.thesis.ID)
+	if err != nil {
+		t.Fatalf("GetThesisByID() error = %v", err)
+	}
+	if thesis.Title != f.thesis.Title || thesis.Student.Nim != f.student.Nim {
+		t.Errorf("thesis = %q student %q, want %q %q", thesis.Title, thesis.Student.Nim, f.thesis.Title, f.student.Nim)
+	}
+	if sp := thesis.Student.StudyProgram; sp.Name != f.program.Name || sp.Faculty.Name != f.faculty.Name {
+		t.Errorf("study program = %q faculty %q, want %q %q", sp.Name, sp.Faculty.Name, f.program.Name, f.faculty.Name)
+	}
+
+	if _, err := f.repo.GetThesisByID(ctx, nil, uuid.New()); !errors.Is(err, dto.ErrNotFound) {
+		t.Errorf("GetThesisByID(unknown) error = %v, want ErrNotFound", err)
+	}
+}
+
+func TestGetThesisSupervisorsByThesisID(t *testing.T) {
+	f := newReadModelFixture(t)
+	ctx := context.Background()
+
+	supervisors, err := f.repo.GetThesisSupervisorsByThesisID(ctx, nil, f.thesis.ID)
+	if err != nil {
+		t.Fatalf("GetThesisSupervisorsByThesisID() error = %v", err)
+	}
+
+	want := []struct {
+		role     entity.Role
+		lecturer entity.Lecturer
+	}{
+		{role: entity.PRIMARY_LECTURER, lecturer: f.primary},
+		{role: entity.SECONDARY_LECTURER, lecturer: f.secondary},
+	}
+	if len(supervisors) != len(want) {
+		t.Fatalf("got %d supervisors, want %d", len(supervisors), len(want))
+	}
+	for i, w := range want {
+		got := supervisors[i]
+		if got.Role != w.role || got.Lecturer.ID != w.lecturer.ID {
+			t.Errorf("supervisors[%d] = %q %s, want %q %s", i, got.Role, got.Lecturer.ID, w.role, w.lecturer.ID)
+		}
+		if got.Lecturer.StudyProgram.Faculty.Name != f.faculty.Name {
+			t.Errorf("supervisors[%d] faculty = %q, want %q", i, got.Lecturer.StudyProgram.Faculty.Name, f.faculty.Name)
+		}
+	}
+
+	supervisors, err = f.repo.GetThesisSupervisorsByThesisID(ctx, nil, uuid.New())
+	if err != nil || len(supervisors) != 0 {
+		t.Errorf("GetThesisSupervisorsByThesisID(unknown) = %d supervisors, %v; want none", len(supervisors), err)
+	}
+}
+
+func TestGetMessagesBySessionID(t *testing.T) {
+	f := newReadModelFixture(t)
+
+	messages, err := f.repo.GetMessagesBySessionID(context.Background(), nil, f.session.ID)
+	if err != nil {
+		t.Fatalf("GetMessagesBySessionID() error = %v", err)
+	}
+	if len(messages) != len(f.messages) {
+		t.Fatalf("got %d messages, want %d", len(messages), len(f.messages))
+	}
+	for i, want := range f.messages {
+		if messages[i].ID != want.ID {
+			t.Errorf("messages[%d] = %q, want %q", i, messages[i].Text, want.Text)
+		}
+		if messages[i].Sender.ID != want.SenderID {
+			t.Errorf("messages[%d] sender = %s, want %s", i, messages[i].Sender.ID, want.SenderID)
+		}
+	}
+	if messages[0].Sender.Student.Name != f.student.Name {
+		t.Errorf("student sender name = %q, want joined %q", messages[0].Sender.Student.Name, f.student.Name)
+	}
+}
+
+func TestGetTaskSummaryBySessionID(t *testing.T) {
+	f := newReadModelFixture(t)
+	ctx := context.Background()
+
+	task, err := f.repo.GetTaskSummaryBySessionID(ctx, nil, f.session.ID)
+	if err != nil {
+		t.Fatalf("GetTaskSummaryBySessionID() error = %v", err)
+	}
+
+	if task.SessionID != f.session.ID || task.SessionStatus != string(entity.FINISHED) {
+		t.Errorf("session = %s %q, want %s %q", task.SessionID, task.SessionStatus, f.session.ID, entity.FINISHED)
+	}
+	if task.StartedAt == nil || !task.StartedAt.Equal(*f.session.StartTime) || task.EndedAt == nil || !task.EndedAt.Equal(*f.session.EndTime) {
+		t.Errorf("started/ended = %v/%v, want %v/%v", task.StartedAt, task.EndedAt, *f.session.StartTime, *f.session.EndTime)
+	}
+	if !task.CreatedAt.Equal(f.session.CreatedAt) {
+		t.Errorf("created_at = %v, want %v", task.CreatedAt, f.session.CreatedAt)
+	}
+
+	wantOwner := dto.CustomUserResponse{ID: f.owner.ID, Name: f.primary.Name, Identifier: f.owner.Identifier, Role: string(entity.LECTURER)}
+	if task.Owner != wantOwner {
+		t.Errorf("owner = %+v, want %+v", task.Owner, wantOwner)
+	}
+
+	wantProgram := dto.StudyProgramResponse{
+		ID: f.program.ID, Name: f.program.Name, Degree: f.program.Degree,
+		Faculty: dto.FacultyResponse{ID: f.faculty.ID, Name: f.faculty.Name},
+	}
+	wantStudent := dto.StudentResponse{ID: f.student.ID, Nim: f.student.Nim, Name: f.student.Name, Email: f.student.Email, StudyProgram: wantProgram}
+	if task.Student != wantStudent {
+		t.Errorf("student = %+v, want %+v", task.Student, wantStudent)
+	}
+
+	wantThesis := dto.ThesisSummary{Title: f.thesis.Title, Description: f.thesis.Description, Progress: f.thesis.Progress}
+	if task.ThesisInfo != wantThesis {
+		t.Errorf("thesis = %+v, want %+v", task.ThesisInfo, wantThesis)
+	}
+
+	wantSupervisors := []dto.LecturerResponse{
+		{ID: f.primary.ID, Nip: f.primary.Nip, Name: f.primary.Name, Email: f.primary.Email, TotalStudent: f.primary.TotalStudent, Role: string(entity.PRIMARY_LECTURER), StudyProgram: wantProgram},
+		{ID: f.secondary.ID, Nip: f.secondary.Nip, Name: f.secondary.Name, Email: f.secondary.Email, TotalStudent: f.secondary.TotalStudent, Role: string(entity.SECONDARY_LECTURER), StudyProgram: wantProgram},
+	}
+	if len(task.Supervisors) != len(wantSupervisors) {
+		t.Fatalf("got %d supervisors, want %d", len(task.Supervisors), len(wantSupervisors))
+	}
+	for i := range wantSupervisors {
+		if task.Supervisors[i] != wantSupervisors[i] {
+			t.Errorf("supervisors[%d] = %+v, want %+v", i, task.Supervisors[i], wantSupervisors[i])
+		}
+	}
+
+	if len(task.Messages) != len(f.messages) {
+		t.Fatalf("got %d messages, want %d", len(task.Messages), len(f.messages))
+	}
+	for i, want := range f.messages {
+		got := task.Messages[i]
+		if got.ID != want.ID || got.Text != want.Text || !got.IsText {
+			t.Errorf("messages[%d] = %+v, want %s %q", i, got, want.ID, want.Text)
+		}
+		if got.Sender.ID != want.SenderID {
+			t.Errorf("messages[%d] sender = %s, want %s", i, got.Sender.ID, want.SenderID)
+		}
+
+		sentAt, err := time.Parse(time.RFC3339Nano, got.Timestamp)
+		if err != nil || !sentAt.Equal(want.SentAt) {
+			t.Errorf("messages[%d] timestamp = %q, want %s", i, got.Timestamp, want.SentAt.Format(time.RFC3339Nano))
+		}
+	}
+	if task.Messages[0].Sender.Name != f.student.Name || task.Messages[1].Sender.Name != f.primary.Name {
+		t.Errorf("sender names = %q, %q; want %q, %q", task.Messages[0].Sender.Name, task.Messages[1].Sender.Name, f.student.Name, f.primary.Name)
+	}
+
+	if _, err := f.repo.GetTaskSummaryBySessionID(ctx, nil, uuid.New()); !errors.Is(err, dto.ErrNotFound) {
+		t.Errorf("GetTaskSummaryBySessionID(unknown) error = %v, want ErrNotFound", err)
+	}
+}
@@ -0,0 +1,33 @@
+package repository
+
+import (
+	"context"
+
+	"gorm.io/gorm"
+)
+
+type (
+	IHealthRepository interface {
+		// READ / GET
+		Ping(ctx context.Context) error
+	}
+
+	healthRepository struct {
+		db *gorm.DB
+	}
+)
+
+func NewHealthRepository(db *gorm.DB) *healthRepository {
+	return &healthRepository{
+		db: db,
+	}
+}
+
+func (hr *healthRepository) Ping(ctx context.Context) error {
+	sqlDB, err := hr.db.DB()
+	if err != nil {
+		return err
+	}
+
+	return sqlDB.PingContext(ctx)
+}
@@ -0,0 +1,105 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/Amierza/worker-service/entity"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type (
+	IOutboxRepository interface {
+		// CREATE / POST
+		CreateOutboxEvents(ctx context.Context, tx *gorm.DB, events []entity.OutboxEvent) error
+
+		// READ / GET
+		GetPendingOutboxEventsForUpdate(ctx context.Context, tx *gorm.DB, limit int) ([]entity.OutboxEvent, error)
+
+		// UPDATE / PATCH
+		MarkOutboxEventSent(ctx context.Context, tx *gorm.DB, id uuid.UUID) error
+		MarkOutboxEventFailed(ctx context.Context, tx *gorm.DB, event entity.OutboxEvent) error
+
+		// DELETE / DELETE
+	}
+
+	outboxRepository struct {
+		db *gorm.DB
+	}
+)
+
+func NewOutboxRepository(db *gorm.DB) *outboxRepository {
+	return &outboxRepository{
+		db: db,
+	}
+}
+
+func (or *outboxRepository) CreateOutboxEvents(ctx context.Context, tx *gorm.DB, events []entity.OutboxEvent) error {
+	if tx == nil {
+		tx = or.db
+	}
+
+	if len(events) == 0 {
+		return nil
+	}
+
+	return tx.WithContext(ctx).Create(&events).Error
+}
+
+// GetPendingOutboxEventsForUpdate mengunci event yang siap dikirim.
+// SKIP LOCKED membuat beberapa replika worker bisa relay bersamaan tanpa saling menunggu
+// dan tanpa mengirim event yang sama dua kali. Harus dipanggil di dalam transaksi.
+func (or *outboxRepository) GetPendingOutboxEventsForUpdate(ctx context.Context, tx *gorm.DB, limit int) ([]entity.OutboxEvent, error) {
+	if tx == nil {
+		tx = or.db
+	}
+
+	var events []entity.OutboxEvent
+	if err := tx.WithContext(ctx).
+		Clauses(clause.Locking{
+			Strength: clause.LockingStrengthUpdate,
+			Options:  clause.LockingOptionsSkipLocked,
+		}).
+		Where("status = ? AND next_attempt_at <= ?", entity.OUTBOX_PENDING, time.Now()).
+		Order("next_attempt_at ASC").
+		Limit(limit).
+		Find(&events).Error; err != nil {
+		return nil, err
+	}
+
+	return events, nil
+}
+
+func (or *outboxRepository) MarkOutboxEventSent(ctx context.Context, tx *gorm.DB, id uuid.UUID) error {
+	if tx == nil {
+		tx = or.db
+	}
+
+	now := time.Now()
+	return tx.WithContext(ctx).
+		Model(&entity.OutboxEvent{}).
+		Where("id = ?", id).
+		Updates(map[string]any{
+			"status":     entity.OUTBOX_SENT,
+			"sent_at":    now,
+			"last_error": "",
+		}).Error
+}
+
+func (or *outboxRepository) MarkOutboxEventFailed(ctx context.Context, tx *gorm.DB, event entity.OutboxEvent) error {
+	if tx == nil {
+		tx = or.db
+	}
+
+	return tx.WithContext(ctx).
+		Model(&entity.OutboxEvent{}).
+		Where("id = ?", event.ID).
+		Updates(map[string]any{
+			"status":          event.Status,
+			"attempts":        event.Attempts,
+			"last_error":      event.LastError,
+			"next_attempt_at": event.NextAttemptAt,
+		}).Error
+}
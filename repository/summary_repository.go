@@ -0,0 +1,102 @@
+package repository
+
+import (
+	"context"
+	"errors"
+
+	"github.com/Amierza/worker-service/dto"
+	"github.com/Amierza/worker-service/entity"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type (
+	ISummaryRepository interface {
+		// READ / GET
+		GetSessionByID(ctx context.Context, tx *gorm.DB, sessionID uuid.UUID) (entity.Session, error)
+		GetThesisByID(ctx context.Context, tx *gorm.DB, thesisID uuid.UUID) (entity.Thesis, error)
+		GetSessionsByThesisID(ctx context.Context, tx *gorm.DB, thesisID uuid.UUID) ([]entity.Session, error)
+		GetSummariesBySessionIDs(ctx context.Context, tx *gorm.DB, sessionIDs []uuid.UUID) ([]entity.Summary, error)
+	}
+
+	summaryRepository struct {
+		db *gorm.DB
+	}
+)
+
+func NewSummaryRepository(db *gorm.DB) *summaryRepository {
+	return &summaryRepository{
+		db: db,
+	}
+}
+
+func (sr *summaryRepository) GetSessionByID(ctx context.Context, tx *gorm.DB, sessionID uuid.UUID) (entity.Session, error) {
+	if tx == nil {
+		tx = sr.db
+	}
+
+	var session entity.Session
+	if err := tx.WithContext(ctx).Where("id = ?", sessionID).Take(&session).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return entity.Session{}, dto.ErrNotFound
+		}
+		return entity.Session{}, err
+	}
+
+	return session, nil
+}
+
+func (sr *summaryRepository) GetThesisByID(ctx context.Context, tx *gorm.DB, thesisID uuid.UUID) (entity.Thesis, error) {
+	if tx == nil {
+		tx = sr.db
+	}
+
+	var thesis entity.Thesis
+	if err := tx.WithContext(ctx).Where("id = ?", thesisID).Take(&thesis).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return entity.Thesis{}, dto.ErrNotFound
+		}
+		return entity.Thesis{}, err
+	}
+
+	return thesis, nil
+}
+
+func (sr *summaryRepository) GetSessionsByThesisID(ctx context.Context, tx *gorm.DB, thesisID uuid.UUID) ([]entity.Session, error) {
+	if tx == nil {
+		tx = sr.db
+	}
+
+	var sessions []entity.Session
+	if err := tx.WithContext(ctx).
+		Where("thesis_id = ?", thesisID).
+		Order("start_time ASC NULLS LAST").
+		Order("created_at ASC").
+		Find(&sessions).Error; err != nil {
+		return nil, err
+	}
+
+	return sessions, nil
+}
+
+// GetSummariesBySessionIDs mengambil semua versi ringkasan, versi terbaru lebih dulu per sesi.
+func (sr *summaryRepository) GetSummariesBySessionIDs(ctx context.Context, tx *gorm.DB, sessionIDs []uuid.UUID) ([]entity.Summary, error) {
+	if tx == nil {
+		tx = sr.db
+	}
+
+	var summaries []entity.Summary
+	if len(sessionIDs) == 0 {
+		return summaries, nil
+	}
+
+	if err := tx.WithContext(ctx).
+		Where("session_id IN ?", sessionIDs).
+		Order("session_id").
+		Order("version DESC").
+		Find(&summaries).Error; err != nil {
+		return nil, err
+	}
+
+	return summaries, nil
+}
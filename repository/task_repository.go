@@ -0,0 +1,173 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"math"
+	"time"
+
+	"github.com/Amierza/worker-service/constants"
+	"github.com/Amierza/worker-service/dto"
+	"github.com/Amierza/worker-service/entity"
+	"github.com/Amierza/worker-service/response"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type (
+	ITaskRepository interface {
+		// CREATE / POST
+		CreateTaskLedger(ctx context.Context, tx *gorm.DB, ledger entity.TaskLedger) error
+		UpsertTaskLedgerStarted(ctx context.Context, tx *gorm.DB, ledger entity.TaskLedger) error
+
+		// READ / GET
+		GetAllTaskLedgersWithPagination(ctx context.Context, tx *gorm.DB, req dto.TaskPaginationRequest) (dto.TaskPaginationRepositoryResponse, error)
+		GetTaskLedgerByID(ctx context.Context, tx *gorm.DB, id uuid.UUID) (entity.TaskLedger, error)
+
+		// UPDATE / PATCH
+		UpdateTaskLedgerResult(ctx context.Context, tx *gorm.DB, id uuid.UUID, status entity.TaskStatus, lastError string, finishedAt time.Time, durationMs int64) error
+		UpdateTaskLedgerStatus(ctx context.Context, tx *gorm.DB, id uuid.UUID, status entity.TaskStatus) error
+
+		// DELETE / DELETE
+	}
+
+	taskRepository struct {
+		db *gorm.DB
+	}
+)
+
+func NewTaskRepository(db *gorm.DB) *taskRepository {
+	return &taskRepository{
+		db: db,
+	}
+}
+
+func (tr *taskRepository) CreateTaskLedger(ctx context.Context, tx *gorm.DB, ledger entity.TaskLedger) error {
+	if tx == nil {
+		tx = tr.db
+	}
+
+	return tx.WithContext(ctx).Create(&ledger).Error
+}
+
+// UpsertTaskLedgerStarted mencatat task mulai diproses. Task yang di-retry memakai baris yang sama:
+// attempts bertambah, sedangkan received_at & payload pertama tetap dipertahankan.
+func (tr *taskRepository) UpsertTaskLedgerStarted(ctx context.Context, tx *gorm.DB, ledger entity.TaskLedger) error {
+	if tx == nil {
+		tx = tr.db
+	}
+
+	return tx.WithContext(ctx).
+		Clauses(clause.OnConflict{
+			Columns: []clause.Column{{Name: "id"}},
+			DoUpdates: clause.Assignments(map[string]any{
+				"status":      ledger.Status,
+				"attempts":    gorm.Expr("task_ledgers.attempts + 1"),
+				"started_at":  ledger.StartedAt,
+				"finished_at": nil,
+				"duration_ms": 0,
+				"session_id":  ledger.SessionID,
+				"updated_at":  time.Now(),
+			}),
+		}).
+		Create(&ledger).Error
+}
+
+func (tr *taskRepository) GetAllTaskLedgersWithPagination(ctx context.Context, tx *gorm.DB, req dto.TaskPaginationRequest) (dto.TaskPaginationRepositoryResponse, error) {
+	if tx == nil {
+		tx = tr.db
+	}
+
+	var (
+		ledgers []entity.TaskLedger
+		count   int64
+	)
+
+	// nilai di luar batas dari query string dijepit, page <= 0 membuat offset negatif
+	if req.PerPage <= 0 {
+		req.PerPage = constants.ENUM_PAGINATION_LIMIT
+	}
+	req.PerPage = min(req.PerPage, constants.ENUM_PAGINATION_MAX_LIMIT)
+
+	if req.Page <= 0 {
+		req.Page = constants.ENUM_PAGINATION_PAGE
+	}
+
+	query := tx.WithContext(ctx).Model(&entity.TaskLedger{})
+
+	if req.Status != "" {
+		query = query.Where("status = ?", req.Status)
+	}
+
+	if req.TaskType != "" {
+		query = query.Where("task_type = ?", req.TaskType)
+	}
+
+	if req.SessionID != "" {
+		query = query.Where("session_id = ?", req.SessionID)
+	}
+
+	if err := query.Session(&gorm.Session{}).Count(&count).Error; err != nil {
+		return dto.TaskPaginationRepositoryResponse{}, err
+	}
+
+	if err := query.Order("received_at DESC").Scopes(response.Paginate(req.Page, req.PerPage)).Find(&ledgers).Error; err != nil {
+		return dto.TaskPaginationRepositoryResponse{}, err
+	}
+
+	totalPage := int64(math.Ceil(float64(count) / float64(req.PerPage)))
+
+	return dto.TaskPaginationRepositoryResponse{
+		Tasks: ledgers,
+		PaginationResponse: response.PaginationResponse{
+			Page:    req.Page,
+			PerPage: req.PerPage,
+			MaxPage: totalPage,
+			Count:   count,
+		},
+	}, nil
+}
+
+func (tr *taskRepository) GetTaskLedgerByID(ctx context.Context, tx *gorm.DB, id uuid.UUID) (entity.TaskLedger, error) {
+	if tx == nil {
+		tx = tr.db
+	}
+
+	var ledger entity.TaskLedger
+	if err := tx.WithContext(ctx).Where("id = ?", id).Take(&ledger).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return entity.TaskLedger{}, dto.ErrNotFound
+		}
+		return entity.TaskLedger{}, err
+	}
+
+	return ledger, nil
+}
+
+func (tr *taskRepository) UpdateTaskLedgerResult(ctx context.Context, tx *gorm.DB, id uuid.UUID, status entity.TaskStatus, lastError string, finishedAt time.Time, durationMs int64) error {
+	if tx == nil {
+		tx = tr.db
+	}
+
+	return tx.WithContext(ctx).
+		Model(&entity.TaskLedger{}).
+		Where("id = ?", id).
+		Updates(map[string]any{
+			"status":      status,
+			"last_error":  lastError,
+			"finished_at": finishedAt,
+			"duration_ms": durationMs,
+		}).Error
+}
+
+func (tr *taskRepository) UpdateTaskLedgerStatus(ctx context.Context, tx *gorm.DB, id uuid.UUID, status entity.TaskStatus) error {
+	if tx == nil {
+		tx = tr.db
+	}
+
+	return tx.WithContext(ctx).
+		Model(&entity.TaskLedger{}).
+		Where("id = ?", id).
+		Update("status", status).Error
+}
@@ -0,0 +1,70 @@
+package repository
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/Amierza/worker-service/constants"
+	"github.com/Amierza/worker-service/dto"
+	"github.com/Amierza/worker-service/entity"
+	"github.com/Amierza/worker-service/internal/testdb"
+	"github.com/Amierza/worker-service/response"
+	"github.com/google/uuid"
+)
+
+func TestGetAllTaskLedgersWithPaginationClampsPage(t *testing.T) {
+	db := testdb.New(t, &entity.TaskLedger{})
+	repo := NewTaskRepository(db)
+
+	const total = constants.ENUM_PAGINATION_MAX_LIMIT + 5
+	ledgers := make([]entity.TaskLedger, 0, total)
+	for i := 0; i < total; i++ {
+		ledgers = append(ledgers, entity.TaskLedger{
+			ID:         uuid.New(),
+			TaskType:   constants.ENUM_TASK_TYPE_SUMMARY,
+			Status:     entity.TASK_SUCCEEDED,
+			ReceivedAt: time.Now().Add(-time.Duration(i) * time.Minute),
+			SessionID:  uuid.New(),
+		})
+	}
+	if err := db.Create(&ledgers).Error; err != nil {
+		t.Fatalf("failed to seed task ledgers: %v", err)
+	}
+
+	tests := []struct {
+		name        string
+		page        int
+		perPage     int
+		wantPage    int
+		wantPerPage int
+		wantFirst   uuid.UUID
+	}{
+		{name: "defaults", page: 0, perPage: 0, wantPage: 1, wantPerPage: constants.ENUM_PAGINATION_LIMIT, wantFirst: ledgers[0].ID},
+		{name: "negative page", page: -3, perPage: 5, wantPage: 1, wantPerPage: 5, wantFirst: ledgers[0].ID},
+		{name: "negative per page", page: 2, perPage: -1, wantPage: 2, wantPerPage: constants.ENUM_PAGINATION_LIMIT, wantFirst: ledgers[constants.ENUM_PAGINATION_LIMIT].ID},
+		{name: "per page above max", page: 1, perPage: 1_000_000, wantPage: 1, wantPerPage: constants.ENUM_PAGINATION_MAX_LIMIT, wantFirst: ledgers[0].ID},
+		{name: "in range", page: 3, perPage: 4, wantPage: 3, wantPerPage: 4, wantFirst: ledgers[8].ID},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			res, err := repo.GetAllTaskLedgersWithPagination(context.Background(), nil, dto.TaskPaginationRequest{
+				PaginationRequest: response.PaginationRequest{Page: tt.page, PerPage: tt.perPage},
+			})
+			if err != nil {
+				t.Fatalf("GetAllTaskLedgersWithPagination() error = %v", err)
+			}
+
+			if res.Page != tt.wantPage || res.PerPage != tt.wantPerPage {
+				t.Errorf("page/per_page = %d/%d, want %d/%d", res.Page, res.PerPage, tt.wantPage, tt.wantPerPage)
+			}
+			if len(res.Tasks) != tt.wantPerPage {
+				t.Errorf("got %d tasks, want %d", len(res.Tasks), tt.wantPerPage)
+			}
+			if len(res.Tasks) > 0 && res.Tasks[0].ID != tt.wantFirst {
+				t.Errorf("first task = %s, want %s", res.Tasks[0].ID, tt.wantFirst)
+			}
+		})
+	}
+}
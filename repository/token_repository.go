@@ -0,0 +1,110 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/Amierza/worker-service/dto"
+	"github.com/Amierza/worker-service/entity"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type (
+	ITokenRepository interface {
+		// CREATE / POST
+		SaveRefreshToken(ctx context.Context, jti, familyID uuid.UUID, userID string, expiresAt time.Time) error
+		RevokeToken(ctx context.Context, jti uuid.UUID, expiresAt time.Time) error
+
+		// READ / GET
+		IsTokenRevoked(ctx context.Context, jti, familyID uuid.UUID) (bool, error)
+
+		// UPDATE / PATCH
+		UseRefreshToken(ctx context.Context, jti uuid.UUID) error
+		RevokeTokenFamily(ctx context.Context, familyID uuid.UUID) error
+
+		// DELETE / DELETE
+	}
+
+	tokenRepository struct {
+		db *gorm.DB
+	}
+)
+
+func NewTokenRepository(db *gorm.DB) *tokenRepository {
+	return &tokenRepository{
+		db: db,
+	}
+}
+
+func (tr *tokenRepository) SaveRefreshToken(ctx context.Context, jti, familyID uuid.UUID, userID string, expiresAt time.Time) error {
+	return tr.db.WithContext(ctx).Create(&entity.RefreshToken{
+		ID:        jti,
+		FamilyID:  familyID,
+		UserID:    userID,
+		ExpiresAt: expiresAt,
+	}).Error
+}
+
+func (tr *tokenRepository) RevokeToken(ctx context.Context, jti uuid.UUID, expiresAt time.Time) error {
+	return tr.db.WithContext(ctx).
+		Clauses(clause.OnConflict{DoNothing: true}).
+		Create(&entity.RevokedToken{
+			ID:        jti,
+			ExpiresAt: expiresAt,
+		}).Error
+}
+
+// IsTokenRevoked bernilai true jika jti dicabut langsung atau family-nya sudah dicabut.
+func (tr *tokenRepository) IsTokenRevoked(ctx context.Context, jti, familyID uuid.UUID) (bool, error) {
+	var revoked bool
+	if err := tr.db.WithContext(ctx).Raw(
+		`SELECT EXISTS (SELECT 1 FROM revoked_tokens WHERE id = ? AND deleted_at IS NULL)
+		OR EXISTS (SELECT 1 FROM refresh_tokens WHERE family_id = ? AND revoked_at IS NOT NULL AND deleted_at IS NULL)`,
+		jti, familyID,
+	).Scan(&revoked).Error; err != nil {
+		return false, err
+	}
+
+	return revoked, nil
+}
+
+// UseRefreshToken menandai refresh token terpakai secara atomik. Token yang sudah terpakai
+// atau dicabut menghasilkan dto.ErrRefreshTokenReused.
+func (tr *tokenRepository) UseRefreshToken(ctx context.Context, jti uuid.UUID) error {
+	now := time.Now()
+
+	result := tr.db.WithContext(ctx).
+		Model(&entity.RefreshToken{}).
+		Where("id = ? AND used_at IS NULL AND revoked_at IS NULL AND expires_at > ?", jti, now).
+		Update("used_at", now)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 1 {
+		return nil
+	}
+
+	var token entity.RefreshToken
+	if err := tr.db.WithContext(ctx).Where("id = ?", jti).Take(&token).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return dto.ErrTokenInvalid
+		}
+		return err
+	}
+
+	if token.UsedAt != nil || token.RevokedAt != nil {
+		return dto.ErrRefreshTokenReused
+	}
+
+	return dto.ErrTokenInvalid
+}
+
+func (tr *tokenRepository) RevokeTokenFamily(ctx context.Context, familyID uuid.UUID) error {
+	return tr.db.WithContext(ctx).
+		Model(&entity.RefreshToken{}).
+		Where("family_id = ? AND revoked_at IS NULL", familyID).
+		Update("revoked_at", time.Now()).Error
+}
@@ -0,0 +1,29 @@
+package repository
+
+import (
+	"context"
+
+	"gorm.io/gorm"
+)
+
+type (
+	ITransactionManager interface {
+		// WithTransaction menjalankan fn di dalam satu transaksi DB.
+		// Transaksi di-rollback jika fn mengembalikan error atau panic.
+		WithTransaction(ctx context.Context, fn func(tx *gorm.DB) error) error
+	}
+
+	transactionManager struct {
+		db *gorm.DB
+	}
+)
+
+func NewTransactionManager(db *gorm.DB) *transactionManager {
+	return &transactionManager{
+		db: db,
+	}
+}
+
+func (tm *transactionManager) WithTransaction(ctx context.Context, fn func(tx *gorm.DB) error) error {
+	return tm.db.WithContext(ctx).Transaction(fn)
+}
@@ -0,0 +1,47 @@
+package repository
+
+import (
+	"context"
+	"errors"
+
+	"github.com/Amierza/worker-service/dto"
+	"github.com/Amierza/worker-service/entity"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type (
+	IUserRepository interface {
+		// READ / GET
+		GetUserLocaleByID(ctx context.Context, tx *gorm.DB, userID uuid.UUID) (string, error)
+	}
+
+	userRepository struct {
+		db *gorm.DB
+	}
+)
+
+func NewUserRepository(db *gorm.DB) *userRepository {
+	return &userRepository{
+		db: db,
+	}
+}
+
+func (ur *userRepository) GetUserLocaleByID(ctx context.Context, tx *gorm.DB, userID uuid.UUID) (string, error) {
+	if tx == nil {
+		tx = ur.db
+	}
+
+	var user entity.User
+	if err := tx.WithContext(ctx).
+		Select("id", "locale").
+		Where("id = ?", userID).
+		Take(&user).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return "", dto.ErrNotFound
+		}
+		return "", err
+	}
+
+	return user.Locale, nil
+}
@@ -1,20 +1,28 @@
 package response
 
-import "time"
+import (
+	"strings"
+	"time"
+
+	"github.com/Amierza/worker-service/apperror"
+	"github.com/Amierza/worker-service/i18n"
+)
 
 type Response struct {
 	Status    bool      `json:"status"`
 	Messsage  string    `json:"message"`
+	Code      string    `json:"code,omitempty"`
 	Timestamp time.Time `json:"timestamp,omitempty"`
 	Data      any       `json:"data,omitempty"`
 	Error     any       `json:"error,omitempty"`
 	Meta      any       `json:"meta,omitempty"`
 }
 
-func BuildResponseSuccess(message string, data any) Response {
+// BuildResponseSuccess menyusun response sukses, message berupa key i18n yang diterjemahkan ke locale.
+func BuildResponseSuccess(locale, message string, data any) Response {
 	res := Response{
 		Status:    true,
-		Messsage:  message,
+		Messsage:  i18n.T(locale, message),
 		Timestamp: time.Now().UTC(),
 		Data:      data,
 	}
@@ -33,3 +41,39 @@ func BuildResponseFailed(message string, err string, data any) Response {
 
 	return res
 }
+
+// BuildResponseError menyusun response gagal dari error beserta status HTTP dan kode error-nya,
+// message & pesan error diterjemahkan ke locale.
+func BuildResponseError(locale, message string, err error) (int, Response) {
+	appErr := apperror.From(err)
+
+	res := Response{
+		Status:    false,
+		Messsage:  i18n.T(locale, message),
+		Code:      appErr.Code,
+		Error:     translateError(locale, appErr, err),
+		Timestamp: time.Now().UTC(),
+	}
+
+	return appErr.Status, res
+}
+
+// translateError mencari terjemahan "error.<CODE>"; cause error hanya ditampilkan untuk 4xx
+// (lewat placeholder {detail} atau ditambahkan di belakang) agar detail internal tidak bocor.
+func translateError(locale string, appErr *apperror.AppError, err error) string {
+	message, ok := i18n.Lookup(locale, "error."+appErr.Code)
+	if !ok {
+		return apperror.PublicMessage(err)
+	}
+
+	if appErr.Status >= 500 || appErr.Cause == nil {
+		return strings.ReplaceAll(message, ": {detail}", "")
+	}
+
+	detail := appErr.Cause.Error()
+	if !strings.Contains(message, "{detail}") {
+		return message + ": " + detail
+	}
+
+	return i18n.Format(message, map[string]any{"detail": detail})
+}
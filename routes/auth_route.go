@@ -0,0 +1,17 @@
+package routes
+
+import (
+	"github.com/Amierza/worker-service/handler"
+	"github.com/Amierza/worker-service/jwt"
+	"github.com/Amierza/worker-service/middleware"
+	"github.com/Amierza/worker-service/service"
+	"github.com/gin-gonic/gin"
+)
+
+func Auth(route *gin.Engine, authHandler handler.IAuthHandler, jwt jwt.IJWT, userService service.IUserService) {
+	routes := route.Group("/api/v1/auth")
+	{
+		routes.POST("/refresh", authHandler.RefreshToken)
+		routes.POST("/logout", middleware.Authentication(jwt), middleware.Locale(userService), authHandler.Logout)
+	}
+}
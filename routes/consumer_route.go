@@ -1,15 +1,25 @@
 package routes
 
 import (
+	"github.com/Amierza/worker-service/constants"
 	"github.com/Amierza/worker-service/handler"
 	"github.com/Amierza/worker-service/jwt"
 	"github.com/Amierza/worker-service/middleware"
+	"github.com/Amierza/worker-service/service"
 	"github.com/gin-gonic/gin"
 )
 
-func Consumer(route *gin.Engine, consumerHandler handler.IConsumerHandler, jwt jwt.IJWT) {
-	routes := route.Group("/api/v1/consumers").Use(middleware.Authentication(jwt))
+func Consumer(route *gin.Engine, consumerHandler handler.IConsumerHandler, jwt jwt.IJWT, apiKeyService service.IAPIKeyService, authorizationService service.IAuthorizationService, userService service.IUserService) {
+	routes := route.Group("/api/v1/consumers").Use(
+		middleware.AuthenticationOrAPIKey(jwt, apiKeyService),
+		middleware.Locale(userService),
+		middleware.Authorize(authorizationService, constants.ENUM_PERMISSION_CONSUMER_CONTROL),
+	)
 	{
-		routes.GET("/start", consumerHandler.StartConsumer)
+		routes.POST("/start", consumerHandler.StartConsumer)
+		routes.POST("/stop", consumerHandler.StopConsumer)
+		routes.POST("/pause", consumerHandler.PauseConsumer)
+		routes.POST("/resume", consumerHandler.ResumeConsumer)
+		routes.GET("/status", consumerHandler.GetConsumerStatus)
 	}
 }
@@ -0,0 +1,12 @@
+package routes
+
+import (
+	"github.com/Amierza/worker-service/handler"
+	"github.com/gin-gonic/gin"
+)
+
+// Health tanpa autentikasi karena dipanggil oleh probe kubernetes.
+func Health(route *gin.Engine, healthHandler handler.IHealthHandler) {
+	route.GET("/healthz", healthHandler.Liveness)
+	route.GET("/readyz", healthHandler.Readiness)
+}
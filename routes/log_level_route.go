@@ -0,0 +1,22 @@
+package routes
+
+import (
+	"github.com/Amierza/worker-service/constants"
+	"github.com/Amierza/worker-service/handler"
+	"github.com/Amierza/worker-service/jwt"
+	"github.com/Amierza/worker-service/middleware"
+	"github.com/Amierza/worker-service/service"
+	"github.com/gin-gonic/gin"
+)
+
+func LogLevel(route *gin.Engine, logLevelHandler handler.ILogLevelHandler, jwt jwt.IJWT, apiKeyService service.IAPIKeyService, authorizationService service.IAuthorizationService, userService service.IUserService) {
+	routes := route.Group("/api/v1/admin").Use(
+		middleware.AuthenticationOrAPIKey(jwt, apiKeyService),
+		middleware.Locale(userService),
+		middleware.Authorize(authorizationService, constants.ENUM_PERMISSION_LOG_LEVEL_WRITE),
+	)
+	{
+		routes.GET("/log-level", logLevelHandler.GetLogLevel)
+		routes.PUT("/log-level", logLevelHandler.UpdateLogLevel)
+	}
+}
@@ -0,0 +1,11 @@
+package routes
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+// Metrics tanpa autentikasi agar bisa di-scrape prometheus.
+func Metrics(route *gin.Engine) {
+	route.GET("/metrics", gin.WrapH(promhttp.Handler()))
+}
@@ -0,0 +1,22 @@
+package routes
+
+import (
+	"github.com/Amierza/worker-service/constants"
+	"github.com/Amierza/worker-service/handler"
+	"github.com/Amierza/worker-service/jwt"
+	"github.com/Amierza/worker-service/middleware"
+	"github.com/Amierza/worker-service/service"
+	"github.com/gin-gonic/gin"
+)
+
+func Summary(route *gin.Engine, summaryHandler handler.ISummaryHandler, jwt jwt.IJWT, authorizationService service.IAuthorizationService, userService service.IUserService) {
+	routes := route.Group("/api/v1").Use(
+		middleware.Authentication(jwt),
+		middleware.Locale(userService),
+		middleware.Authorize(authorizationService, constants.ENUM_PERMISSION_SUMMARIES_READ),
+	)
+	{
+		routes.GET("/sessions/:id/summary", middleware.SessionAccess(authorizationService, "id"), summaryHandler.GetSessionSummary)
+		routes.GET("/theses/:id/summaries", middleware.ThesisAccess(authorizationService, "id"), summaryHandler.GetThesisSummaries)
+	}
+}
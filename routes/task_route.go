@@ -0,0 +1,23 @@
+package routes
+
+import (
+	"github.com/Amierza/worker-service/constants"
+	"github.com/Amierza/worker-service/handler"
+	"github.com/Amierza/worker-service/jwt"
+	"github.com/Amierza/worker-service/middleware"
+	"github.com/Amierza/worker-service/service"
+	"github.com/gin-gonic/gin"
+)
+
+func Task(route *gin.Engine, taskHandler handler.ITaskHandler, jwt jwt.IJWT, apiKeyService service.IAPIKeyService, authorizationService service.IAuthorizationService, userService service.IUserService) {
+	routes := route.Group("/api/v1/admin").Use(
+		middleware.AuthenticationOrAPIKey(jwt, apiKeyService),
+		middleware.Locale(userService),
+	)
+	{
+		routes.GET("/tasks", middleware.Authorize(authorizationService, constants.ENUM_PERMISSION_TASKS_READ), taskHandler.GetAllTasks)
+		routes.GET("/tasks/:id", middleware.Authorize(authorizationService, constants.ENUM_PERMISSION_TASKS_READ), taskHandler.GetTaskDetail)
+		routes.POST("/tasks/:id/retry", middleware.Authorize(authorizationService, constants.ENUM_PERMISSION_TASKS_RETRY), taskHandler.RetryTask)
+		routes.POST("/sessions/:id/resummarize", middleware.Authorize(authorizationService, constants.ENUM_PERMISSION_SESSIONS_RESUMMARIZE), taskHandler.ResummarizeSession)
+	}
+}
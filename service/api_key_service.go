@@ -0,0 +1,137 @@
+package service
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/Amierza/worker-service/constants"
+	"github.com/Amierza/worker-service/dto"
+	"github.com/Amierza/worker-service/entity"
+	"github.com/Amierza/worker-service/repository"
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+type (
+	IAPIKeyService interface {
+		MintAPIKey(ctx context.Context, req dto.MintAPIKeyRequest) (dto.MintAPIKeyResponse, error)
+		RevokeAPIKey(ctx context.Context, id string) error
+		AuthenticateAPIKey(ctx context.Context, rawKey string) (entity.APIKey, error)
+	}
+
+	apiKeyService struct {
+		apiKeyRepo repository.IAPIKeyRepository
+		logger     *zap.Logger
+	}
+)
+
+// apiKeyScopes adalah scope yang boleh diberikan ke API key; namanya sama dengan permission role.
+var apiKeyScopes = []string{
+	constants.ENUM_PERMISSION_SUMMARIES_READ,
+	constants.ENUM_PERMISSION_TASKS_READ,
+	constants.ENUM_PERMISSION_TASKS_RETRY,
+	constants.ENUM_PERMISSION_SESSIONS_RESUMMARIZE,
+	constants.ENUM_PERMISSION_CONSUMER_CONTROL,
+	constants.ENUM_PERMISSION_LOG_LEVEL_WRITE,
+}
+
+func NewAPIKeyService(apiKeyRepo repository.IAPIKeyRepository, logger *zap.Logger) *apiKeyService {
+	return &apiKeyService{
+		apiKeyRepo: apiKeyRepo,
+		logger:     logger,
+	}
+}
+
+// MintAPIKey membuat key baru. Nilai key hanya dikembalikan di sini dan tidak bisa dilihat lagi.
+func (as *apiKeyService) MintAPIKey(ctx context.Context, req dto.MintAPIKeyRequest) (dto.MintAPIKeyResponse, error) {
+	if strings.TrimSpace(req.Name) == "" {
+		return dto.MintAPIKeyResponse{}, dto.ErrAPIKeyNameRequired
+	}
+
+	if len(req.Scopes) == 0 {
+		return dto.MintAPIKeyResponse{}, fmt.Errorf("%w: at least one scope is required", dto.ErrInvalidAPIKeyScope)
+	}
+	for _, scope := range req.Scopes {
+		if !slices.Contains(apiKeyScopes, scope) {
+			return dto.MintAPIKeyResponse{}, fmt.Errorf("%w: %q", dto.ErrInvalidAPIKeyScope, scope)
+		}
+	}
+
+	secret := make([]byte, constants.ENUM_API_KEY_BYTES)
+	if _, err := rand.Read(secret); err != nil {
+		return dto.MintAPIKeyResponse{}, err
+	}
+	rawKey := constants.ENUM_API_KEY_PREFIX + base64.RawURLEncoding.EncodeToString(secret)
+
+	apiKey := entity.APIKey{
+		ID:      uuid.New(),
+		Name:    strings.TrimSpace(req.Name),
+		Prefix:  rawKey[:constants.ENUM_API_KEY_DISPLAY_PREFIX_LEN],
+		KeyHash: hashAPIKey(rawKey),
+		Scopes:  strings.Join(req.Scopes, " "),
+	}
+	if req.ExpiresIn > 0 {
+		expiresAt := time.Now().Add(req.ExpiresIn)
+		apiKey.ExpiresAt = &expiresAt
+	}
+
+	if err := as.apiKeyRepo.CreateAPIKey(ctx, nil, apiKey); err != nil {
+		return dto.MintAPIKeyResponse{}, err
+	}
+
+	return dto.MintAPIKeyResponse{
+		ID:        apiKey.ID,
+		Name:      apiKey.Name,
+		Key:       rawKey,
+		Scopes:    apiKey.ScopeList(),
+		ExpiresAt: apiKey.ExpiresAt,
+	}, nil
+}
+
+func (as *apiKeyService) RevokeAPIKey(ctx context.Context, id string) error {
+	keyID, err := uuid.Parse(id)
+	if err != nil {
+		return dto.ErrInvalidAPIKeyID
+	}
+
+	return as.apiKeyRepo.RevokeAPIKey(ctx, nil, keyID)
+}
+
+func (as *apiKeyService) AuthenticateAPIKey(ctx context.Context, rawKey string) (entity.APIKey, error) {
+	if !strings.HasPrefix(rawKey, constants.ENUM_API_KEY_PREFIX) {
+		return entity.APIKey{}, dto.ErrAPIKeyInvalid
+	}
+
+	apiKey, err := as.apiKeyRepo.GetAPIKeyByHash(ctx, nil, hashAPIKey(rawKey))
+	if err != nil {
+		if errors.Is(err, dto.ErrNotFound) {
+			return entity.APIKey{}, dto.ErrAPIKeyInvalid
+		}
+		return entity.APIKey{}, err
+	}
+
+	now := time.Now()
+	if !apiKey.IsActive(now) {
+		return entity.APIKey{}, dto.ErrAPIKeyExpired
+	}
+
+	if err := as.apiKeyRepo.TouchAPIKeyLastUsed(ctx, nil, apiKey.ID, now, constants.ENUM_API_KEY_LAST_USED_THROTTLE*time.Second); err != nil {
+		as.logger.Warn("failed to update api key last used", zap.String("api_key_id", apiKey.ID.String()), zap.Error(err))
+	}
+
+	return apiKey, nil
+}
+
+// hashAPIKey memakai SHA-256 biasa karena key berentropi tinggi (32 byte acak), bukan password.
+func hashAPIKey(rawKey string) string {
+	sum := sha256.Sum256([]byte(rawKey))
+	return hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,59 @@
+package service
+
+import (
+	"context"
+	"errors"
+
+	"github.com/Amierza/worker-service/dto"
+	"github.com/Amierza/worker-service/jwt"
+	"go.uber.org/zap"
+)
+
+type (
+	IAuthService interface {
+		RefreshToken(ctx context.Context, req dto.RefreshTokenRequest) (dto.TokenResponse, error)
+		Logout(ctx context.Context, accessToken string, req dto.LogoutRequest) error
+	}
+
+	authService struct {
+		jwt    jwt.IJWT
+		logger *zap.Logger
+	}
+)
+
+func NewAuthService(jwt jwt.IJWT, logger *zap.Logger) *authService {
+	return &authService{
+		jwt:    jwt,
+		logger: logger,
+	}
+}
+
+func (as *authService) RefreshToken(ctx context.Context, req dto.RefreshTokenRequest) (dto.TokenResponse, error) {
+	accessToken, refreshToken, err := as.jwt.RefreshToken(ctx, req.RefreshToken)
+	if err != nil {
+		if errors.Is(err, dto.ErrRefreshTokenReused) {
+			as.logger.Warn("refresh token reuse detected, token family revoked")
+		}
+		return dto.TokenResponse{}, err
+	}
+
+	return dto.TokenResponse{
+		AccessToken:  accessToken,
+		RefreshToken: refreshToken,
+	}, nil
+}
+
+// Logout mencabut access token yang sedang dipakai dan, jika dikirim, refresh token beserta family-nya.
+func (as *authService) Logout(ctx context.Context, accessToken string, req dto.LogoutRequest) error {
+	if err := as.jwt.RevokeToken(ctx, accessToken); err != nil {
+		return err
+	}
+
+	if req.RefreshToken != "" {
+		if err := as.jwt.RevokeToken(ctx, req.RefreshToken); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,131 @@
+package service
+
+import (
+	"context"
+
+	"github.com/Amierza/worker-service/constants"
+	"github.com/Amierza/worker-service/dto"
+	"github.com/Amierza/worker-service/entity"
+	"github.com/Amierza/worker-service/repository"
+	"github.com/google/uuid"
+)
+
+type (
+	IAuthorizationService interface {
+		HasPermission(role string, permission string) bool
+		CanAccessSession(ctx context.Context, userID, role, sessionID string) error
+		CanAccessThesis(ctx context.Context, userID, role, thesisID string) error
+	}
+
+	authorizationService struct {
+		authorizationRepo repository.IAuthorizationRepository
+	}
+)
+
+// participantPermissions dipakai bersama oleh mahasiswa & semua role dosen: sama-sama hanya membaca
+// ringkasan, sedangkan sesi/thesis mana yang boleh dibaca dibatasi CanAccessSession & CanAccessThesis.
+var participantPermissions = []string{
+	constants.ENUM_PERMISSION_SUMMARIES_READ,
+}
+
+// rolePermissions adalah matriks role -> permission. Role yang tidak terdaftar tidak punya permission apa pun.
+var rolePermissions = map[entity.Role][]string{
+	entity.STUDENT:            participantPermissions,
+	entity.LECTURER:           participantPermissions,
+	entity.PRIMARY_LECTURER:   participantPermissions,
+	entity.SECONDARY_LECTURER: participantPermissions,
+	entity.ADMIN: {
+		constants.ENUM_PERMISSION_SUMMARIES_READ,
+		constants.ENUM_PERMISSION_TASKS_READ,
+		constants.ENUM_PERMISSION_TASKS_RETRY,
+		constants.ENUM_PERMISSION_SESSIONS_RESUMMARIZE,
+		constants.ENUM_PERMISSION_CONSUMER_CONTROL,
+		constants.ENUM_PERMISSION_LOG_LEVEL_WRITE,
+	},
+}
+
+func NewAuthorizationService(authorizationRepo repository.IAuthorizationRepository) *authorizationService {
+	return &authorizationService{
+		authorizationRepo: authorizationRepo,
+	}
+}
+
+func (as *authorizationService) HasPermission(role string, permission string) bool {
+	for _, p := range rolePermissions[entity.Role(role)] {
+		if p == permission {
+			return true
+		}
+	}
+
+	return false
+}
+
+// CanAccessSession mengizinkan admin, pemilik sesi, mahasiswa pemilik thesis, dan dosen pembimbing thesis.
+// Selain admin, sesi yang tidak ada dan sesi tanpa akses sama-sama ErrNotFound agar id tidak bisa ditebak.
+func (as *authorizationService) CanAccessSession(ctx context.Context, userID, role, sessionID string) error {
+	sid, err := uuid.Parse(sessionID)
+	if err != nil {
+		return dto.ErrInvalidSessionID
+	}
+
+	isAdmin := entity.Role(role) == entity.ADMIN
+
+	var uid uuid.UUID
+	if !isAdmin {
+		if uid, err = uuid.Parse(userID); err != nil {
+			return dto.ErrAccessDenied
+		}
+	}
+
+	session, err := as.authorizationRepo.GetSessionOwnership(ctx, nil, sid)
+	if err != nil {
+		return err
+	}
+
+	if isAdmin || session.UserIDOwner == uid {
+		return nil
+	}
+
+	return as.checkThesisMember(ctx, uid, session.ThesisID)
+}
+
+// CanAccessThesis mengizinkan admin, mahasiswa pemilik thesis, dan dosen pembimbing thesis.
+// Selain admin, keanggotaan dicek lebih dulu sehingga keberadaan thesis tidak bocor.
+func (as *authorizationService) CanAccessThesis(ctx context.Context, userID, role, thesisID string) error {
+	tid, err := uuid.Parse(thesisID)
+	if err != nil {
+		return dto.ErrInvalidThesisID
+	}
+
+	if entity.Role(role) != entity.ADMIN {
+		uid, err := uuid.Parse(userID)
+		if err != nil {
+			return dto.ErrAccessDenied
+		}
+
+		return as.checkThesisMember(ctx, uid, tid)
+	}
+
+	exists, err := as.authorizationRepo.IsThesisExists(ctx, nil, tid)
+	if err != nil {
+		return err
+	}
+	if !exists {
+		return dto.ErrNotFound
+	}
+
+	return nil
+}
+
+func (as *authorizationService) checkThesisMember(ctx context.Context, userID, thesisID uuid.UUID) error {
+	member, err := as.authorizationRepo.IsThesisMember(ctx, nil, userID, thesisID)
+	if err != nil {
+		return err
+	}
+	// disamakan dengan resource yang tidak ada, lihat CanAccessSession
+	if !member {
+		return dto.ErrNotFound
+	}
+
+	return nil
+}
@@ -0,0 +1,117 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/Amierza/worker-service/constants"
+	"github.com/Amierza/worker-service/dto"
+	"github.com/Amierza/worker-service/entity"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// fakeAuthorizationRepo menyimpan sesi, thesis, dan anggota thesis di memori.
+type fakeAuthorizationRepo struct {
+	sessions map[uuid.UUID]entity.Session
+	theses   map[uuid.UUID]bool
+	members  map[uuid.UUID]map[uuid.UUID]bool // thesis -> user
+}
+
+func (r fakeAuthorizationRepo) GetSessionOwnership(_ context.Context, _ *gorm.DB, sessionID uuid.UUID) (entity.Session, error) {
+	session, ok := r.sessions[sessionID]
+	if !ok {
+		return entity.Session{}, dto.ErrNotFound
+	}
+	return session, nil
+}
+
+func (r fakeAuthorizationRepo) IsThesisExists(_ context.Context, _ *gorm.DB, thesisID uuid.UUID) (bool, error) {
+	return r.theses[thesisID], nil
+}
+
+func (r fakeAuthorizationRepo) IsThesisMember(_ context.Context, _ *gorm.DB, userID, thesisID uuid.UUID) (bool, error) {
+	return r.members[thesisID][userID], nil
+}
+
+func TestCanAccessSessionAndThesis(t *testing.T) {
+	var (
+		thesisID  = uuid.New()
+		sessionID = uuid.New()
+		ownerID   = uuid.New()
+		studentID = uuid.New()
+		outsider  = uuid.New()
+		missing   = uuid.New()
+	)
+
+	as := NewAuthorizationService(fakeAuthorizationRepo{
+		sessions: map[uuid.UUID]entity.Session{sessionID: {ID: sessionID, ThesisID: thesisID, UserIDOwner: ownerID}},
+		theses:   map[uuid.UUID]bool{thesisID: true},
+		members:  map[uuid.UUID]map[uuid.UUID]bool{thesisID: {studentID: true}},
+	})
+
+	tests := []struct {
+		name    string
+		check   func(ctx context.Context, userID, role, id string) error
+		userID  string
+		role    entity.Role
+		id      string
+		wantErr error
+	}{
+		{name: "session admin", check: as.CanAccessSession, userID: outsider.String(), role: entity.ADMIN, id: sessionID.String()},
+		{name: "session admin missing", check: as.CanAccessSession, userID: outsider.String(), role: entity.ADMIN, id: missing.String(), wantErr: dto.ErrNotFound},
+		{name: "session owner", check: as.CanAccessSession, userID: ownerID.String(), role: entity.LECTURER, id: sessionID.String()},
+		{name: "session thesis member", check: as.CanAccessSession, userID: studentID.String(), role: entity.STUDENT, id: sessionID.String()},
+		// tanpa akses dan tidak ada harus tidak bisa dibedakan
+		{name: "session outsider", check: as.CanAccessSession, userID: outsider.String(), role: entity.STUDENT, id: sessionID.String(), wantErr: dto.ErrNotFound},
+		{name: "session outsider missing", check: as.CanAccessSession, userID: outsider.String(), role: entity.STUDENT, id: missing.String(), wantErr: dto.ErrNotFound},
+		{name: "session invalid id", check: as.CanAccessSession, userID: outsider.String(), role: entity.STUDENT, id: "bukan-uuid", wantErr: dto.ErrInvalidSessionID},
+
+		{name: "thesis admin", check: as.CanAccessThesis, userID: outsider.String(), role: entity.ADMIN, id: thesisID.String()},
+		{name: "thesis admin missing", check: as.CanAccessThesis, userID: outsider.String(), role: entity.ADMIN, id: missing.String(), wantErr: dto.ErrNotFound},
+		{name: "thesis member", check: as.CanAccessThesis, userID: studentID.String(), role: entity.STUDENT, id: thesisID.String()},
+		{name: "thesis outsider", check: as.CanAccessThesis, userID: outsider.String(), role: entity.LECTURER, id: thesisID.String(), wantErr: dto.ErrNotFound},
+		{name: "thesis outsider missing", check: as.CanAccessThesis, userID: outsider.String(), role: entity.LECTURER, id: missing.String(), wantErr: dto.ErrNotFound},
+		{name: "thesis invalid id", check: as.CanAccessThesis, userID: outsider.String(), role: entity.LECTURER, id: "bukan-uuid", wantErr: dto.ErrInvalidThesisID},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.check(context.Background(), tt.userID, string(tt.role), tt.id)
+			if tt.wantErr == nil && err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if tt.wantErr != nil && !errors.Is(err, tt.wantErr) {
+				t.Fatalf("error = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestHasPermission(t *testing.T) {
+	as := NewAuthorizationService(fakeAuthorizationRepo{})
+
+	tests := []struct {
+		role       entity.Role
+		permission string
+		want       bool
+	}{
+		{role: entity.STUDENT, permission: constants.ENUM_PERMISSION_SUMMARIES_READ, want: true},
+		{role: entity.LECTURER, permission: constants.ENUM_PERMISSION_SUMMARIES_READ, want: true},
+		{role: entity.PRIMARY_LECTURER, permission: constants.ENUM_PERMISSION_SUMMARIES_READ, want: true},
+		{role: entity.SECONDARY_LECTURER, permission: constants.ENUM_PERMISSION_SUMMARIES_READ, want: true},
+		{role: entity.PRIMARY_LECTURER, permission: constants.ENUM_PERMISSION_TASKS_RETRY, want: false},
+		{role: entity.STUDENT, permission: constants.ENUM_PERMISSION_CONSUMER_CONTROL, want: false},
+		{role: entity.ADMIN, permission: constants.ENUM_PERMISSION_LOG_LEVEL_WRITE, want: true},
+		{role: "guest", permission: constants.ENUM_PERMISSION_SUMMARIES_READ, want: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(string(tt.role)+" "+tt.permission, func(t *testing.T) {
+			if got := as.HasPermission(string(tt.role), tt.permission); got != tt.want {
+				t.Errorf("HasPermission(%q, %q) = %v, want %v", tt.role, tt.permission, got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,199 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/Amierza/worker-service/constants"
+	"github.com/Amierza/worker-service/dto"
+	"github.com/Amierza/worker-service/entity"
+	"github.com/Amierza/worker-service/repository"
+	"github.com/google/uuid"
+	amqp "github.com/rabbitmq/amqp091-go"
+	"go.uber.org/zap"
+)
+
+type (
+	IBackfillService interface {
+		RunBackfill(ctx context.Context, req dto.BackfillRequest) (dto.BackfillResult, error)
+	}
+
+	backfillService struct {
+		backfillRepo    repository.IBackfillRepository
+		consumerRepo    repository.IConsumerRepository
+		consumerService IConsumerService
+		logger          *zap.Logger
+		rabbitmq        *amqp.Connection
+	}
+)
+
+func NewBackfillService(backfillRepo repository.IBackfillRepository, consumerRepo repository.IConsumerRepository, consumerService IConsumerService, logger *zap.Logger, rabbitmq *amqp.Connection) *backfillService {
+	return &backfillService{
+		backfillRepo:    backfillRepo,
+		consumerRepo:    consumerRepo,
+		consumerService: consumerService,
+		logger:          logger,
+		rabbitmq:        rabbitmq,
+	}
+}
+
+// RunBackfill meringkas sesi lama yang belum punya ringkasan dengan laju terbatas.
+// Posisi terakhir disimpan sebagai checkpoint sehingga bisa dilanjutkan setelah terhenti.
+// Checkpoint tidak maju melewati sesi yang gagal: run berikutnya mengulang dari sesi gagal
+// pertama, sesi sesudahnya yang sudah punya ringkasan tidak lagi menjadi kandidat.
+func (bs *backfillService) RunBackfill(ctx context.Context, req dto.BackfillRequest) (dto.BackfillResult, error) {
+	var result dto.BackfillResult
+
+	if req.Mode != constants.ENUM_BACKFILL_MODE_ENQUEUE && req.Mode != constants.ENUM_BACKFILL_MODE_PROCESS {
+		return result, fmt.Errorf("%w: %q", dto.ErrInvalidBackfillMode, req.Mode)
+	}
+	if !(req.Rate > 0) {
+		return result, dto.ErrInvalidBackfillRate
+	}
+
+	checkpoint := entity.BackfillCheckpoint{
+		Name:            req.Name,
+		FilterFacultyID: req.Filter.FacultyID,
+		FilterFrom:      req.Filter.From,
+		FilterTo:        req.Filter.To,
+	}
+	if !req.Reset {
+		saved, err := bs.backfillRepo.GetBackfillCheckpoint(ctx, nil, req.Name)
+		if err != nil && !errors.Is(err, dto.ErrNotFound) {
+			return result, fmt.Errorf("failed to load checkpoint: %w", err)
+		}
+		if err == nil {
+			if !sameBackfillFilter(saved, checkpoint) {
+				return result, fmt.Errorf("%w: checkpoint %q, use --reset or another --checkpoint", dto.ErrBackfillFilterMismatched, req.Name)
+			}
+
+			checkpoint = saved
+			bs.logger.Info("resuming backfill from checkpoint",
+				zap.String("checkpoint", checkpoint.Name),
+				zap.String("last_session_id", checkpoint.LastSessionID.String()),
+				zap.Time("last_created_at", checkpoint.LastCreatedAt),
+			)
+		}
+	}
+
+	var ch *amqp.Channel
+	if req.Mode == constants.ENUM_BACKFILL_MODE_ENQUEUE && !req.DryRun {
+		var err error
+		ch, err = bs.rabbitmq.Channel()
+		if err != nil {
+			return result, fmt.Errorf("failed to open channel: %w", err)
+		}
+		defer ch.Close()
+
+		if err := ch.Confirm(false); err != nil {
+			return result, fmt.Errorf("failed to enable publisher confirm: %w", err)
+		}
+		if err := declareSummaryQueues(ch); err != nil {
+			return result, err
+		}
+	}
+
+	// rate di atas 1e9 per detik menghasilkan interval 0 dan membuat NewTicker panic
+	interval := max(time.Duration(float64(time.Second)/req.Rate), time.Nanosecond)
+	throttle := time.NewTicker(interval)
+	defer throttle.Stop()
+
+	// cursor selalu maju untuk paging, checkpoint berhenti di sesi sebelum kegagalan pertama
+	cursor := checkpoint
+	failed := false
+	for {
+		sessions, err := bs.backfillRepo.GetBackfillCandidates(ctx, nil, req.Filter, cursor, constants.ENUM_BACKFILL_BATCH_SIZE)
+		if err != nil {
+			return result, fmt.Errorf("failed to get backfill candidates: %w", err)
+		}
+		if len(sessions) == 0 {
+			bs.logger.Info("backfill finished", zap.Any("result", result))
+			return result, nil
+		}
+
+		for _, session := range sessions {
+			if req.Limit > 0 && result.Scanned >= req.Limit {
+				bs.logger.Info("backfill limit reached", zap.Any("result", result))
+				return result, nil
+			}
+
+			select {
+			case <-ctx.Done():
+				bs.logger.Info("backfill interrupted", zap.Any("result", result))
+				return result, ctx.Err()
+			case <-throttle.C:
+			}
+
+			result.Scanned++
+			result.LastSessionID = session.ID
+			cursor.LastCreatedAt = session.CreatedAt
+			cursor.LastSessionID = session.ID
+
+			if req.DryRun {
+				bs.logger.Info("[dry-run] would backfill session",
+					zap.String("session_id", session.ID.String()),
+					zap.String("status", string(session.Status)),
+					zap.Time("created_at", session.CreatedAt),
+				)
+				continue
+			}
+
+			if err := bs.backfillSession(ctx, ch, req.Mode, session.ID); err != nil {
+				bs.logger.Error("failed to backfill session",
+					zap.String("session_id", session.ID.String()),
+					zap.Error(err),
+				)
+				result.Failed++
+				checkpoint.Failed++
+				failed = true
+			} else {
+				result.Succeeded++
+				checkpoint.Processed++
+				if !failed {
+					checkpoint.LastCreatedAt = session.CreatedAt
+					checkpoint.LastSessionID = session.ID
+				}
+			}
+
+			if err := bs.backfillRepo.SaveBackfillCheckpoint(ctx, nil, checkpoint); err != nil {
+				return result, fmt.Errorf("failed to save checkpoint: %w", err)
+			}
+		}
+	}
+}
+
+func (bs *backfillService) backfillSession(ctx context.Context, ch *amqp.Channel, mode string, sessionID uuid.UUID) error {
+	task, err := bs.consumerRepo.GetTaskSummaryBySessionID(ctx, nil, sessionID)
+	if err != nil {
+		return fmt.Errorf("failed to rebuild task: %w", err)
+	}
+
+	if mode == constants.ENUM_BACKFILL_MODE_PROCESS {
+		return bs.consumerService.ProcessSummaryTask(ctx, task)
+	}
+
+	body, err := json.Marshal(task)
+	if err != nil {
+		return fmt.Errorf("failed to encode task: %w", err)
+	}
+
+	return publishSummaryTask(ctx, ch, uuid.New(), body)
+}
+
+// sameBackfillFilter membandingkan filter yang tersimpan di dua checkpoint.
+func sameBackfillFilter(a, b entity.BackfillCheckpoint) bool {
+	sameTime := func(x, y *time.Time) bool {
+		if x == nil || y == nil {
+			return x == y
+		}
+		return x.Equal(*y)
+	}
+
+	sameFaculty := a.FilterFacultyID == nil && b.FilterFacultyID == nil ||
+		a.FilterFacultyID != nil && b.FilterFacultyID != nil && *a.FilterFacultyID == *b.FilterFacultyID
+
+	return sameFaculty && sameTime(a.FilterFrom, b.FilterFrom) && sameTime(a.FilterTo, b.FilterTo)
+}
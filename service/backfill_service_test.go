@@ -0,0 +1,233 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/Amierza/worker-service/constants"
+	"github.com/Amierza/worker-service/dto"
+	"github.com/Amierza/worker-service/entity"
+	"github.com/Amierza/worker-service/internal/testdb"
+	"github.com/Amierza/worker-service/repository"
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+// taskStubConsumerRepo membangun ulang task hanya dari session id, cukup untuk backfill.
+type taskStubConsumerRepo struct {
+	repository.IConsumerRepository
+}
+
+func (taskStubConsumerRepo) GetTaskSummaryBySessionID(_ context.Context, _ *gorm.DB, sessionID uuid.UUID) (dto.TaskSummary, error) {
+	return dto.TaskSummary{SessionID: sessionID}, nil
+}
+
+// recordingConsumerService mencatat sesi yang diproses dan gagal untuk sesi di failOn.
+type recordingConsumerService struct {
+	IConsumerService
+	processed []uuid.UUID
+	failOn    map[uuid.UUID]bool
+}
+
+func (s *recordingConsumerService) ProcessSummaryTask(_ context.Context, task dto.TaskSummary) error {
+	s.processed = append(s.processed, task.SessionID)
+	if s.failOn[task.SessionID] {
+		return errInjected
+	}
+	return nil
+}
+
+type backfillServiceFixture struct {
+	db       *gorm.DB
+	repo     repository.IBackfillRepository
+	sessions []uuid.UUID
+}
+
+func newBackfillServiceFixture(t *testing.T) backfillServiceFixture {
+	t.Helper()
+
+	db := testdb.New(t, &entity.Session{}, &entity.Summary{}, &entity.BackfillCheckpoint{})
+
+	base := time.Now().Add(-48 * time.Hour).Truncate(time.Second)
+	var sessions []uuid.UUID
+	for i := 0; i < 4; i++ {
+		session := entity.Session{
+			ID:        uuid.New(),
+			Status:    entity.FINISHED,
+			TimeStamp: entity.TimeStamp{CreatedAt: base.Add(time.Duration(i) * time.Minute)},
+		}
+		if err := db.Create(&session).Error; err != nil {
+			t.Fatalf("failed to seed session: %v", err)
+		}
+		sessions = append(sessions, session.ID)
+	}
+
+	return backfillServiceFixture{db: db, repo: repository.NewBackfillRepository(db), sessions: sessions}
+}
+
+func (f backfillServiceFixture) run(t *testing.T, consumer *recordingConsumerService, req dto.BackfillRequest) dto.BackfillResult {
+	t.Helper()
+
+	result, err := f.runErr(consumer, req)
+	if err != nil {
+		t.Fatalf("RunBackfill: %v", err)
+	}
+
+	return result
+}
+
+func (f backfillServiceFixture) runErr(consumer *recordingConsumerService, req dto.BackfillRequest) (dto.BackfillResult, error) {
+	req.Name = "test"
+	if req.Rate == 0 {
+		req.Rate = 1000
+	}
+	if req.Mode == "" {
+		req.Mode = constants.ENUM_BACKFILL_MODE_PROCESS
+	}
+	req.Filter.StuckAfter = time.Hour
+
+	bs := NewBackfillService(f.repo, taskStubConsumerRepo{}, consumer, zap.NewNop(), nil)
+	return bs.RunBackfill(context.Background(), req)
+}
+
+func (f backfillServiceFixture) checkpoint(t *testing.T) entity.BackfillCheckpoint {
+	t.Helper()
+
+	checkpoint, err := f.repo.GetBackfillCheckpoint(context.Background(), nil, "test")
+	if err != nil {
+		t.Fatalf("GetBackfillCheckpoint: %v", err)
+	}
+
+	return checkpoint
+}
+
+func assertProcessed(t *testing.T, got, want []uuid.UUID) {
+	t.Helper()
+
+	if len(got) != len(want) {
+		t.Fatalf("processed %d sessions, want %d", len(got), len(want))
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("processed[%d] = %s, want %s", i, got[i], want[i])
+		}
+	}
+}
+
+func TestRunBackfillResumesFromCheckpoint(t *testing.T) {
+	f := newBackfillServiceFixture(t)
+
+	first := &recordingConsumerService{}
+	result := f.run(t, first, dto.BackfillRequest{Limit: 2})
+	assertProcessed(t, first.processed, f.sessions[:2])
+	if result.Succeeded != 2 || result.LastSessionID != f.sessions[1] {
+		t.Errorf("first run result = %+v, want 2 succeeded ending at %s", result, f.sessions[1])
+	}
+	if cp := f.checkpoint(t); cp.LastSessionID != f.sessions[1] || cp.Processed != 2 {
+		t.Errorf("checkpoint after first run = %+v, want last %s processed 2", cp, f.sessions[1])
+	}
+
+	// run kedua melanjutkan setelah checkpoint, tanpa mengulang sesi yang sudah diproses
+	second := &recordingConsumerService{}
+	f.run(t, second, dto.BackfillRequest{})
+	assertProcessed(t, second.processed, f.sessions[2:])
+	if cp := f.checkpoint(t); cp.LastSessionID != f.sessions[3] || cp.Processed != 4 {
+		t.Errorf("checkpoint after second run = %+v, want last %s processed 4", cp, f.sessions[3])
+	}
+
+	// --reset mengabaikan checkpoint
+	reset := &recordingConsumerService{}
+	f.run(t, reset, dto.BackfillRequest{Reset: true})
+	assertProcessed(t, reset.processed, f.sessions)
+}
+
+func TestRunBackfillDoesNotAdvanceCheckpointPastFailures(t *testing.T) {
+	f := newBackfillServiceFixture(t)
+
+	consumer := &recordingConsumerService{failOn: map[uuid.UUID]bool{f.sessions[1]: true}}
+	result := f.run(t, consumer, dto.BackfillRequest{})
+	if result.Succeeded != 3 || result.Failed != 1 {
+		t.Errorf("result = %+v, want 3 succeeded 1 failed", result)
+	}
+
+	cp := f.checkpoint(t)
+	if cp.LastSessionID != f.sessions[0] || cp.Processed != 3 || cp.Failed != 1 {
+		t.Errorf("checkpoint = %+v, want last %s processed 3 failed 1", cp, f.sessions[0])
+	}
+
+	// run berikutnya mengulang dari sesi yang gagal
+	retry := &recordingConsumerService{}
+	f.run(t, retry, dto.BackfillRequest{})
+	assertProcessed(t, retry.processed, f.sessions[1:])
+	if cp := f.checkpoint(t); cp.LastSessionID != f.sessions[3] {
+		t.Errorf("checkpoint after retry = %+v, want last %s", cp, f.sessions[3])
+	}
+}
+
+func TestRunBackfillRejectsCheckpointWithDifferentFilter(t *testing.T) {
+	f := newBackfillServiceFixture(t)
+	f.run(t, &recordingConsumerService{}, dto.BackfillRequest{Limit: 1})
+
+	from := time.Now().Add(-72 * time.Hour)
+	facultyID := uuid.New()
+	tests := []struct {
+		name   string
+		filter dto.BackfillFilter
+	}{
+		{name: "from added", filter: dto.BackfillFilter{From: &from}},
+		{name: "to added", filter: dto.BackfillFilter{To: &from}},
+		{name: "faculty added", filter: dto.BackfillFilter{FacultyID: &facultyID}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			consumer := &recordingConsumerService{}
+			_, err := f.runErr(consumer, dto.BackfillRequest{Filter: tt.filter})
+			if !errors.Is(err, dto.ErrBackfillFilterMismatched) {
+				t.Fatalf("RunBackfill() error = %v, want ErrBackfillFilterMismatched", err)
+			}
+			if len(consumer.processed) != 0 {
+				t.Errorf("processed %d sessions with mismatched filter, want 0", len(consumer.processed))
+			}
+		})
+	}
+
+	// --reset memulai checkpoint baru dengan filter yang baru
+	reset := &recordingConsumerService{}
+	f.run(t, reset, dto.BackfillRequest{Reset: true, Filter: dto.BackfillFilter{From: &from}})
+	assertProcessed(t, reset.processed, f.sessions)
+	if cp := f.checkpoint(t); cp.FilterFrom == nil || !cp.FilterFrom.Equal(from) {
+		t.Errorf("checkpoint filter_from = %v, want %v", cp.FilterFrom, from)
+	}
+	f.run(t, &recordingConsumerService{}, dto.BackfillRequest{Filter: dto.BackfillFilter{From: &from}})
+}
+
+func TestRunBackfillClampsThrottleInterval(t *testing.T) {
+	f := newBackfillServiceFixture(t)
+
+	// interval 1s/rate dibulatkan ke 0 untuk rate > 1e9, NewTicker(0) panic
+	consumer := &recordingConsumerService{}
+	f.run(t, consumer, dto.BackfillRequest{Rate: 2e9})
+	assertProcessed(t, consumer.processed, f.sessions)
+}
+
+func TestRunBackfillDryRunDoesNotTouchCheckpoint(t *testing.T) {
+	f := newBackfillServiceFixture(t)
+
+	// rabbitmq nil: dry-run mode enqueue tidak boleh membuka channel
+	consumer := &recordingConsumerService{}
+	result := f.run(t, consumer, dto.BackfillRequest{Mode: constants.ENUM_BACKFILL_MODE_ENQUEUE, DryRun: true})
+	if result.Scanned != len(f.sessions) {
+		t.Errorf("scanned = %d, want %d", result.Scanned, len(f.sessions))
+	}
+	if len(consumer.processed) != 0 {
+		t.Errorf("dry-run processed %d sessions, want 0", len(consumer.processed))
+	}
+
+	if _, err := f.repo.GetBackfillCheckpoint(context.Background(), nil, "test"); !errors.Is(err, dto.ErrNotFound) {
+		t.Errorf("GetBackfillCheckpoint after dry-run error = %v, want ErrNotFound", err)
+	}
+}
@@ -0,0 +1,236 @@
+package service
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/Amierza/worker-service/constants"
+	"github.com/Amierza/worker-service/dto"
+	"go.uber.org/zap"
+)
+
+type (
+	IConsumerControllerService interface {
+		Start(ctx context.Context) error
+		Stop(ctx context.Context) error
+		Pause(ctx context.Context) error
+		Resume(ctx context.Context) error
+		Status(ctx context.Context) dto.ConsumerStatusResponse
+	}
+
+	consumerControllerService struct {
+		consumerService IConsumerService
+		logger          *zap.Logger
+
+		// baseCtx = umur aplikasi, bukan umur request HTTP
+		baseCtx context.Context
+
+		mu        sync.Mutex
+		state     string
+		cancel    context.CancelFunc
+		done      chan struct{}
+		startedAt *time.Time
+
+		// dipisah dari mu karena ditulis goroutine run yang tidak boleh menunggu lock controller
+		errMu       sync.Mutex
+		lastError   string
+		lastErrorAt *time.Time
+	}
+)
+
+func NewConsumerControllerService(baseCtx context.Context, consumerService IConsumerService, logger *zap.Logger) *consumerControllerService {
+	return &consumerControllerService{
+		consumerService: consumerService,
+		logger:          logger,
+		baseCtx:         baseCtx,
+		state:           constants.ENUM_CONSUMER_STATE_STOPPED,
+	}
+}
+
+func (ccs *consumerControllerService) Start(ctx context.Context) error {
+	ccs.mu.Lock()
+	defer ccs.mu.Unlock()
+
+	if ccs.state != constants.ENUM_CONSUMER_STATE_STOPPED {
+		return dto.ErrConsumerAlreadyRunning
+	}
+	if err := ccs.launch(); err != nil {
+		return err
+	}
+	ccs.logger.Info("consumer started")
+	return nil
+}
+
+func (ccs *consumerControllerService) Stop(ctx context.Context) error {
+	ccs.mu.Lock()
+	if ccs.state == constants.ENUM_CONSUMER_STATE_STOPPED {
+		ccs.mu.Unlock()
+		return dto.ErrConsumerNotRunning
+	}
+
+	done := ccs.halt()
+	ccs.state = constants.ENUM_CONSUMER_STATE_STOPPED
+	ccs.startedAt = nil
+	ccs.mu.Unlock()
+
+	ccs.wait(ctx, done)
+	ccs.logger.Info("consumer stopped")
+	return nil
+}
+
+// Pause berhenti mengambil pesan baru (pesan tetap di queue) sampai Resume dipanggil.
+func (ccs *consumerControllerService) Pause(ctx context.Context) error {
+	ccs.mu.Lock()
+	if ccs.state != constants.ENUM_CONSUMER_STATE_RUNNING {
+		ccs.mu.Unlock()
+		return dto.ErrConsumerNotRunning
+	}
+
+	done := ccs.halt()
+	ccs.state = constants.ENUM_CONSUMER_STATE_PAUSED
+	ccs.mu.Unlock()
+
+	ccs.wait(ctx, done)
+	ccs.logger.Info("consumer paused")
+	return nil
+}
+
+func (ccs *consumerControllerService) Resume(ctx context.Context) error {
+	ccs.mu.Lock()
+	defer ccs.mu.Unlock()
+
+	if ccs.state != constants.ENUM_CONSUMER_STATE_PAUSED {
+		return dto.ErrConsumerNotPaused
+	}
+
+	startedAt := ccs.startedAt
+	if err := ccs.launch(); err != nil {
+		return err
+	}
+	ccs.startedAt = startedAt // uptime dihitung sejak Start, bukan sejak Resume
+	ccs.logger.Info("consumer resumed")
+	return nil
+}
+
+func (ccs *consumerControllerService) Status(ctx context.Context) dto.ConsumerStatusResponse {
+	ccs.mu.Lock()
+	defer ccs.mu.Unlock()
+
+	stats := ccs.consumerService.Stats()
+	res := dto.ConsumerStatusResponse{
+		State:                  ccs.state,
+		StartedAt:              ccs.startedAt,
+		InFlight:               stats.InFlight,
+		Processed:              stats.Processed,
+		Failed:                 stats.Failed,
+		LastError:              stats.LastError,
+		LastErrorAt:            stats.LastErrorAt,
+		LastProcessedSessionID: stats.LastProcessedSessionID,
+		LastProcessedAt:        stats.LastProcessedAt,
+	}
+	if ccs.startedAt != nil {
+		res.UptimeSeconds = int64(time.Since(*ccs.startedAt).Seconds())
+	}
+
+	// error loop consumer (mis. channel tertutup) bisa lebih baru dari error task
+	ccs.errMu.Lock()
+	if ccs.lastErrorAt != nil && (res.LastErrorAt == nil || ccs.lastErrorAt.After(*res.LastErrorAt)) {
+		res.LastError = ccs.lastError
+		res.LastErrorAt = ccs.lastErrorAt
+	}
+	ccs.errMu.Unlock()
+
+	return res
+}
+
+// launch harus dipanggil dengan mu terkunci. Goroutine baru tidak dibuat selama goroutine
+// sebelumnya belum selesai, agar tidak ada dua consumer yang berjalan bersamaan.
+func (ccs *consumerControllerService) launch() error {
+	if ccs.done != nil {
+		select {
+		case <-ccs.done:
+		default:
+			return dto.ErrConsumerStopping
+		}
+	}
+
+	runCtx, cancel := context.WithCancel(ccs.baseCtx)
+	done := make(chan struct{})
+	now := time.Now()
+
+	ccs.cancel = cancel
+	ccs.done = done
+	ccs.startedAt = &now
+	ccs.state = constants.ENUM_CONSUMER_STATE_RUNNING
+
+	go ccs.run(runCtx, done)
+	return nil
+}
+
+// halt membatalkan loop dan mengembalikan channel done miliknya. Harus dipanggil dengan mu
+// terkunci; menunggu done dilakukan lewat wait setelah mu dilepas agar Status tidak ikut tertahan.
+func (ccs *consumerControllerService) halt() chan struct{} {
+	if ccs.cancel != nil {
+		ccs.cancel()
+	}
+
+	// done tetap disimpan sampai launch berikutnya sebagai penanda goroutine lama masih hidup
+	return ccs.done
+}
+
+// wait menunggu task yang sedang berjalan selesai atau ctx habis.
+func (ccs *consumerControllerService) wait(ctx context.Context, done chan struct{}) {
+	if done == nil {
+		return
+	}
+
+	select {
+	case <-done:
+	case <-ctx.Done():
+		ccs.logger.Warn("timed out waiting for consumer to stop, it will finish in background")
+	}
+}
+
+// run menjalankan consumer dan me-restart dengan backoff jika loop berhenti karena error.
+// Backoff kembali ke minimum setiap kali consumer berhasil terdaftar, supaya putus sesaat
+// setelah berjalan lama tidak menunggu backoff maksimum.
+func (ccs *consumerControllerService) run(ctx context.Context, done chan struct{}) {
+	defer close(done)
+
+	minBackoff := constants.ENUM_CONSUMER_RESTART_MIN_BACKOFF * time.Millisecond
+	backoff := minBackoff
+	for {
+		err := ccs.consumerService.ConsumeSummaryTasks(ctx, func() {
+			backoff = minBackoff
+		})
+		if ctx.Err() != nil {
+			return
+		}
+
+		if err != nil {
+			ccs.logger.Error("consumer loop stopped, restarting", zap.Error(err), zap.Duration("backoff", backoff))
+			ccs.setLastError(err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(backoff):
+		}
+
+		backoff *= 2
+		if maxBackoff := constants.ENUM_CONSUMER_RESTART_MAX_BACKOFF * time.Millisecond; backoff > maxBackoff {
+			backoff = maxBackoff
+		}
+	}
+}
+
+func (ccs *consumerControllerService) setLastError(err error) {
+	ccs.errMu.Lock()
+	defer ccs.errMu.Unlock()
+
+	now := time.Now()
+	ccs.lastError = err.Error()
+	ccs.lastErrorAt = &now
+}
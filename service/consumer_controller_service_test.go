@@ -0,0 +1,173 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/Amierza/worker-service/constants"
+	"github.com/Amierza/worker-service/dto"
+	"go.uber.org/zap"
+)
+
+// blockingConsumer mensimulasikan task in-flight: setelah ctx dibatalkan, loop baru keluar
+// ketika release ditutup. running/maxRunning mendeteksi dua loop yang berjalan bersamaan.
+type blockingConsumer struct {
+	IConsumerService
+	cancelled chan struct{}
+	release   chan struct{}
+
+	mu         sync.Mutex
+	running    int
+	maxRunning int
+	once       sync.Once
+}
+
+func newBlockingConsumer() *blockingConsumer {
+	return &blockingConsumer{cancelled: make(chan struct{}), release: make(chan struct{})}
+}
+
+func (c *blockingConsumer) ConsumeSummaryTasks(ctx context.Context, started func()) error {
+	c.mu.Lock()
+	c.running++
+	c.maxRunning = max(c.maxRunning, c.running)
+	c.mu.Unlock()
+
+	defer func() {
+		c.mu.Lock()
+		c.running--
+		c.mu.Unlock()
+	}()
+
+	<-ctx.Done()
+	c.once.Do(func() { close(c.cancelled) })
+	<-c.release
+	return nil
+}
+
+func (c *blockingConsumer) Stats() dto.ConsumerStats {
+	return dto.ConsumerStats{}
+}
+
+func (c *blockingConsumer) maxConcurrent() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.maxRunning
+}
+
+func waitFor(t *testing.T, ch <-chan struct{}, what string) {
+	t.Helper()
+
+	select {
+	case <-ch:
+	case <-time.After(2 * time.Second):
+		t.Fatalf("timed out waiting for %s", what)
+	}
+}
+
+func TestConsumerControllerStatusDoesNotBlockWhileStopping(t *testing.T) {
+	tests := []struct {
+		name      string
+		halt      func(ccs *consumerControllerService, ctx context.Context) error
+		wantState string
+	}{
+		{name: "stop", halt: (*consumerControllerService).Stop, wantState: constants.ENUM_CONSUMER_STATE_STOPPED},
+		{name: "pause", halt: (*consumerControllerService).Pause, wantState: constants.ENUM_CONSUMER_STATE_PAUSED},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			consumer := newBlockingConsumer()
+			ccs := NewConsumerControllerService(context.Background(), consumer, zap.NewNop())
+			if err := ccs.Start(context.Background()); err != nil {
+				t.Fatalf("Start: %v", err)
+			}
+
+			halted := make(chan error, 1)
+			go func() { halted <- tt.halt(ccs, context.Background()) }()
+			waitFor(t, consumer.cancelled, "consumer cancellation")
+
+			// task masih in-flight: Status harus tetap bisa dibaca
+			status := make(chan dto.ConsumerStatusResponse, 1)
+			go func() { status <- ccs.Status(context.Background()) }()
+			select {
+			case got := <-status:
+				if got.State != tt.wantState {
+					t.Errorf("state = %s, want %s", got.State, tt.wantState)
+				}
+			case <-time.After(2 * time.Second):
+				t.Fatal("Status blocked while waiting for the in-flight task")
+			}
+
+			select {
+			case err := <-halted:
+				t.Fatalf("%s returned before the in-flight task finished (err: %v)", tt.name, err)
+			default:
+			}
+
+			close(consumer.release)
+			select {
+			case err := <-halted:
+				if err != nil {
+					t.Errorf("%s: %v", tt.name, err)
+				}
+			case <-time.After(2 * time.Second):
+				t.Fatalf("%s did not return after the in-flight task finished", tt.name)
+			}
+		})
+	}
+}
+
+func TestConsumerControllerDoesNotRelaunchBeforePreviousLoopExits(t *testing.T) {
+	tests := []struct {
+		name     string
+		halt     func(ccs *consumerControllerService, ctx context.Context) error
+		relaunch func(ccs *consumerControllerService, ctx context.Context) error
+	}{
+		{name: "pause then resume", halt: (*consumerControllerService).Pause, relaunch: (*consumerControllerService).Resume},
+		{name: "stop then start", halt: (*consumerControllerService).Stop, relaunch: (*consumerControllerService).Start},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			consumer := newBlockingConsumer()
+			ccs := NewConsumerControllerService(context.Background(), consumer, zap.NewNop())
+			if err := ccs.Start(context.Background()); err != nil {
+				t.Fatalf("Start: %v", err)
+			}
+
+			// ctx request sudah habis: halt kembali tanpa menunggu task selesai
+			expired, cancel := context.WithCancel(context.Background())
+			cancel()
+			if err := tt.halt(ccs, expired); err != nil {
+				t.Fatalf("halt: %v", err)
+			}
+			waitFor(t, consumer.cancelled, "consumer cancellation")
+
+			if err := tt.relaunch(ccs, context.Background()); !errors.Is(err, dto.ErrConsumerStopping) {
+				t.Fatalf("relaunch while previous loop alive error = %v, want ErrConsumerStopping", err)
+			}
+
+			close(consumer.release)
+			ccs.mu.Lock()
+			done := ccs.done
+			ccs.mu.Unlock()
+			waitFor(t, done, "previous loop exit")
+
+			if err := tt.relaunch(ccs, context.Background()); err != nil {
+				t.Fatalf("relaunch after previous loop exited: %v", err)
+			}
+			if got := ccs.Status(context.Background()).State; got != constants.ENUM_CONSUMER_STATE_RUNNING {
+				t.Errorf("state = %s, want running", got)
+			}
+			if err := ccs.Stop(context.Background()); err != nil {
+				t.Fatalf("Stop: %v", err)
+			}
+			if got := consumer.maxConcurrent(); got != 1 {
+				t.Errorf("max concurrent consumer loops = %d, want 1", got)
+			}
+		})
+	}
+}
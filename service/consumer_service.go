@@ -3,67 +3,110 @@ package service
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
 	"time"
 
 	pb "github.com/Amierza/ai-service/proto"
+	"github.com/Amierza/worker-service/constants"
 	"github.com/Amierza/worker-service/dto"
+	"github.com/Amierza/worker-service/entity"
 	grpcclient "github.com/Amierza/worker-service/grpc_client"
+	"github.com/Amierza/worker-service/helper"
+	"github.com/Amierza/worker-service/i18n"
 	"github.com/Amierza/worker-service/jwt"
+	"github.com/Amierza/worker-service/logger"
+	"github.com/Amierza/worker-service/metrics"
 	"github.com/Amierza/worker-service/repository"
+	"github.com/Amierza/worker-service/tracing"
+	"github.com/google/uuid"
 	amqp "github.com/rabbitmq/amqp091-go"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	semconv "go.opentelemetry.io/otel/semconv/v1.37.0"
+	"go.opentelemetry.io/otel/trace"
 	"go.uber.org/zap"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/encoding/protojson"
+	"gorm.io/gorm"
 )
 
 type (
 	IConsumerService interface {
-		ConsumeSummaryTasks(ctx context.Context) error
+		ConsumeSummaryTasks(ctx context.Context, started func()) error
+		ProcessSummaryTask(ctx context.Context, task dto.TaskSummary) error
+		IsRunning() bool
+		IsChannelOpen() bool
+		Stats() dto.ConsumerStats
+	}
+
+	// amqpPublisher dipenuhi *amqp.Channel; handleDelivery hanya butuh publish untuk retry / DLQ.
+	amqpPublisher interface {
+		PublishWithContext(ctx context.Context, exchange, key string, mandatory, immediate bool, msg amqp.Publishing) error
 	}
 
 	consumerService struct {
 		consumerRepo repository.IConsumerRepository
+		outboxRepo   repository.IOutboxRepository
+		taskRepo     repository.ITaskRepository
+		txManager    repository.ITransactionManager
 		logger       *zap.Logger
 		rabbitmq     *amqp.Connection
 		jwt          jwt.IJWT
-		grpcClient   *grpcclient.SummaryClient
+		grpcClient   grpcclient.ISummaryClient
+
+		// taskTimeout membatasi satu ProcessSummaryTask, lihat config.WorkerConfig
+		taskTimeout time.Duration
+
+		running  atomic.Bool
+		channel  atomic.Pointer[amqp.Channel]
+		inFlight atomic.Int64
+
+		statsMu sync.RWMutex
+		stats   dto.ConsumerStats
 	}
 )
 
-func NewConsumerService(consumerRepo repository.IConsumerRepository, logger *zap.Logger, rabbitmq *amqp.Connection, jwt jwt.IJWT, grpcClient *grpcclient.SummaryClient) *consumerService {
+func NewConsumerService(consumerRepo repository.IConsumerRepository, outboxRepo repository.IOutboxRepository, taskRepo repository.ITaskRepository, txManager repository.ITransactionManager, logger *zap.Logger, rabbitmq *amqp.Connection, jwt jwt.IJWT, grpcClient grpcclient.ISummaryClient, taskTimeout time.Duration) *consumerService {
 	return &consumerService{
 		consumerRepo: consumerRepo,
+		outboxRepo:   outboxRepo,
+		taskRepo:     taskRepo,
+		txManager:    txManager,
 		logger:       logger,
 		rabbitmq:     rabbitmq,
 		jwt:          jwt,
 		grpcClient:   grpcClient,
+		taskTimeout:  taskTimeout,
 	}
 }
 
-func (cs *consumerService) ConsumeSummaryTasks(ctx context.Context) error {
+// ConsumeSummaryTasks memblok sampai ctx selesai atau channel tertutup; started (boleh nil)
+// dipanggil sekali setelah consumer terdaftar di broker.
+func (cs *consumerService) ConsumeSummaryTasks(ctx context.Context, started func()) error {
 	ch, err := cs.rabbitmq.Channel()
 	if err != nil {
 		return fmt.Errorf("failed to open channel: %w", err)
 	}
 	defer ch.Close()
 
-	queueName := "summary_task"
+	if err := declareSummaryQueues(ch); err != nil {
+		return err
+	}
 
-	_, err = ch.QueueDeclare(
-		queueName,
-		true,  // durable
-		false, // auto-delete
-		false, // exclusive
-		false, // no-wait
-		nil,
-	)
-	if err != nil {
-		return fmt.Errorf("failed to declare queue: %w", err)
+	// satu pesan per worker sampai di-ack, supaya pesan yang belum selesai tidak menumpuk
+	if err := ch.Qos(1, 0, false); err != nil {
+		return fmt.Errorf("failed to set qos: %w", err)
 	}
 
 	msgs, err := ch.Consume(
-		queueName,
+		constants.ENUM_QUEUE_SUMMARY_TASK,
 		"",
-		true,  // auto-ack
+		false, // manual ack, ack setelah transaksi commit
 		false, // exclusive
 		false, // no-local
 		false, // no-wait
@@ -73,7 +116,19 @@ func (cs *consumerService) ConsumeSummaryTasks(ctx context.Context) error {
 		return fmt.Errorf("failed to start consumer: %w", err)
 	}
 
+	cs.channel.Store(ch)
+	cs.running.Store(true)
+	metrics.ConsumerRunning.Set(1)
+	defer func() {
+		cs.running.Store(false)
+		cs.channel.Store(nil)
+		metrics.ConsumerRunning.Set(0)
+	}()
+
 	cs.logger.Info("✅ Worker started listening for summary tasks...")
+	if started != nil {
+		started()
+	}
 
 	// Loop terus-menerus di sini (tidak di goroutine lain)
 	for {
@@ -88,120 +143,621 @@ func (cs *consumerService) ConsumeSummaryTasks(ctx context.Context) error {
 				return fmt.Errorf("channel closed")
 			}
 
-			var task dto.TaskSummary
-			if err := json.Unmarshal(msg.Body, &task); err != nil {
-				cs.logger.Error("failed to unmarshal task", zap.Error(err))
-				continue
-			}
+			// task yang sedang berjalan diselesaikan dulu walaupun consumer diminta berhenti,
+			// dibatasi taskTimeout di ProcessSummaryTask
+			cs.handleDelivery(context.WithoutCancel(ctx), ch, msg)
+		}
+	}
+}
+
+// IsRunning menandakan loop consumer sedang aktif menerima pesan.
+func (cs *consumerService) IsRunning() bool {
+	return cs.running.Load()
+}
+
+// IsChannelOpen menandakan channel AMQP milik consumer masih terbuka.
+func (cs *consumerService) IsChannelOpen() bool {
+	ch := cs.channel.Load()
+	return ch != nil && !ch.IsClosed()
+}
+
+func (cs *consumerService) Stats() dto.ConsumerStats {
+	cs.statsMu.RLock()
+	defer cs.statsMu.RUnlock()
+
+	stats := cs.stats
+	stats.InFlight = cs.inFlight.Load()
+	return stats
+}
+
+func (cs *consumerService) recordProcessed(sessionID uuid.UUID) {
+	cs.statsMu.Lock()
+	defer cs.statsMu.Unlock()
+
+	now := time.Now()
+	cs.stats.Processed++
+	cs.stats.LastProcessedSessionID = &sessionID
+	cs.stats.LastProcessedAt = &now
+}
+
+func (cs *consumerService) recordError(err error) {
+	cs.statsMu.Lock()
+	defer cs.statsMu.Unlock()
+
+	now := time.Now()
+	cs.stats.Failed++
+	cs.stats.LastError = err.Error()
+	cs.stats.LastErrorAt = &now
+}
+
+type queueSpec struct {
+	name string
+	args amqp.Table
+}
+
+func summaryQueues() []queueSpec {
+	return []queueSpec{
+		{name: constants.ENUM_QUEUE_SUMMARY_TASK},
+		{
+			// pesan retry menunggu di sini sampai expired lalu dikembalikan ke queue utama
+			name: constants.ENUM_QUEUE_SUMMARY_TASK_RETRY,
+			args: amqp.Table{
+				"x-dead-letter-exchange":    "",
+				"x-dead-letter-routing-key": constants.ENUM_QUEUE_SUMMARY_TASK,
+			},
+		},
+		{name: constants.ENUM_QUEUE_SUMMARY_TASK_DLQ},
+	}
+}
+
+func declareSummaryQueues(ch *amqp.Channel) error {
+	for _, q := range summaryQueues() {
+		_, err := ch.QueueDeclare(
+			q.name,
+			true,  // durable
+			false, // auto-delete
+			false, // exclusive
+			false, // no-wait
+			q.args,
+		)
+		if err != nil {
+			return fmt.Errorf("failed to declare queue %s: %w", q.name, err)
+		}
+	}
+
+	return nil
+}
+
+func (cs *consumerService) handleDelivery(ctx context.Context, ch amqpPublisher, msg amqp.Delivery) {
+	start := time.Now()
+	metrics.TasksReceived.WithLabelValues(constants.ENUM_TASK_TYPE_SUMMARY).Inc()
+	metrics.TasksInFlight.WithLabelValues(constants.ENUM_TASK_TYPE_SUMMARY).Inc()
+	cs.inFlight.Add(1)
+	defer func() {
+		metrics.TasksInFlight.WithLabelValues(constants.ENUM_TASK_TYPE_SUMMARY).Dec()
+		cs.inFlight.Add(-1)
+	}()
+
+	taskID := taskIDFromDelivery(msg)
+	correlationID := correlationIDFromDelivery(msg, taskID)
+
+	// span task menjadi anak dari span publisher lewat header traceparent (W3C)
+	ctx, span := tracing.Tracer().Start(tracing.ExtractAMQP(ctx, msg.Headers), constants.ENUM_QUEUE_SUMMARY_TASK+" process",
+		trace.WithSpanKind(trace.SpanKindConsumer),
+		trace.WithAttributes(
+			semconv.MessagingSystemRabbitMQ,
+			semconv.MessagingOperationTypeProcess,
+			semconv.MessagingDestinationName(constants.ENUM_QUEUE_SUMMARY_TASK),
+			semconv.MessagingMessageID(msg.MessageId),
+			semconv.MessagingMessageConversationID(correlationID),
+			semconv.MessagingRabbitMQMessageDeliveryTag(int(msg.DeliveryTag)),
+			attribute.String("task.id", taskID.String()),
+			attribute.Int("task.retry_count", retryCount(msg)),
+		),
+	)
+	defer span.End()
+
+	// semua log milik task ini, termasuk statement DB, membawa field yang sama
+	// sehingga satu grep correlation_id / task_id menemukan seluruh siklusnya
+	log := cs.logger.With(
+		zap.String("task_id", taskID.String()),
+		zap.String("correlation_id", correlationID),
+		zap.String("message_id", msg.MessageId),
+		zap.Uint64("delivery_tag", msg.DeliveryTag),
+		zap.Int("retry_count", retryCount(msg)),
+	)
+	if span.SpanContext().HasTraceID() {
+		log = log.With(zap.String("trace_id", span.SpanContext().TraceID().String()))
+	}
+
+	_, decodeSpan := tracing.Tracer().Start(ctx, constants.ENUM_QUEUE_SUMMARY_TASK+" decode")
+	var task dto.TaskSummary
+	err := json.Unmarshal(msg.Body, &task)
+	if err != nil {
+		err = fmt.Errorf("%w: %v", dto.ErrInvalidTaskPayload, err)
+	} else {
+		log = log.With(zap.String("session_id", task.SessionID.String()))
+		span.SetAttributes(attribute.String("session.id", task.SessionID.String()))
+	}
+	tracing.End(decodeSpan, err)
+	ctx = logger.WithCorrelationID(logger.WithContext(ctx, log), correlationID)
+
+	cs.markTaskStarted(ctx, taskID, task.SessionID, msg.Body, start)
+
+	if err == nil {
+		err = cs.ProcessSummaryTask(ctx, task)
+	}
+
+	if err == nil {
+		metrics.TasksSucceeded.WithLabelValues(constants.ENUM_TASK_TYPE_SUMMARY).Inc()
+		metrics.TaskDuration.WithLabelValues(constants.ENUM_TASK_TYPE_SUMMARY, constants.ENUM_TASK_STATUS_SUCCEEDED).Observe(time.Since(start).Seconds())
+		cs.markTaskFinished(ctx, taskID, entity.TASK_SUCCEEDED, nil, start)
+
+		if err := msg.Ack(false); err != nil {
+			log.Error("failed to ack message", zap.Error(err))
+		}
+		return
+	}
+
+	cs.recordError(err)
+	span.SetAttributes(attribute.String("error.type", errorClass(err)))
+	span.RecordError(err)
+	span.SetStatus(codes.Error, err.Error())
+	metrics.TasksFailed.WithLabelValues(constants.ENUM_TASK_TYPE_SUMMARY, errorClass(err)).Inc()
+	metrics.TaskDuration.WithLabelValues(constants.ENUM_TASK_TYPE_SUMMARY, constants.ENUM_TASK_STATUS_FAILED).Observe(time.Since(start).Seconds())
+
+	log.Error("failed to process summary task", zap.Error(err))
+
+	deadLettered, pubErr := cs.retryOrDeadLetter(ctx, ch, msg, taskID, err)
+	if pubErr != nil {
+		// gagal republish, kembalikan pesan ke queue agar tidak hilang
+		log.Error("failed to republish failed task, requeueing", zap.Error(pubErr))
+		cs.markTaskFinished(ctx, taskID, entity.TASK_QUEUED, err, start)
+		if err := msg.Nack(false, true); err != nil {
+			log.Error("failed to nack message", zap.Error(err))
+		}
+		return
+	}
+
+	status := entity.TASK_RETRYING
+	if deadLettered {
+		status = entity.TASK_DEAD_LETTERED
+	}
+	cs.markTaskFinished(ctx, taskID, status, err, start)
+
+	if err := msg.Ack(false); err != nil {
+		log.Error("failed to ack message", zap.Error(err))
+	}
+}
+
+// markTaskStarted & markTaskFinished menulis task ledger secara best-effort:
+// kegagalan hanya di-log agar tidak mengganggu pemrosesan task.
+func (cs *consumerService) markTaskStarted(ctx context.Context, taskID, sessionID uuid.UUID, body []byte, startedAt time.Time) {
+	ledger := entity.TaskLedger{
+		ID:         taskID,
+		TaskType:   constants.ENUM_TASK_TYPE_SUMMARY,
+		Status:     entity.TASK_PROCESSING,
+		Attempts:   1,
+		ReceivedAt: startedAt,
+		StartedAt:  &startedAt,
+		SessionID:  sessionID,
+	}
+	if json.Valid(body) {
+		ledger.Payload = body
+	}
+
+	if err := cs.taskRepo.UpsertTaskLedgerStarted(ctx, nil, ledger); err != nil {
+		logger.FromContext(ctx, cs.logger).Error("failed to record task start", zap.Error(err))
+	}
+}
+
+func (cs *consumerService) markTaskFinished(ctx context.Context, taskID uuid.UUID, status entity.TaskStatus, cause error, startedAt time.Time) {
+	var lastError string
+	if cause != nil {
+		lastError = cause.Error()
+	}
+
+	finishedAt := time.Now()
+	if err := cs.taskRepo.UpdateTaskLedgerResult(ctx, nil, taskID, status, lastError, finishedAt, finishedAt.Sub(startedAt).Milliseconds()); err != nil {
+		logger.FromContext(ctx, cs.logger).Error("failed to record task result", zap.Error(err))
+	}
+}
+
+// ProcessSummaryTask membuat ringkasan lewat AI service lalu menyimpan hasilnya dalam satu transaksi.
+// Deadline taskTimeout hanya berlaku di sini, sehingga ack, retry dan ledger di pemanggil tetap jalan.
+func (cs *consumerService) ProcessSummaryTask(ctx context.Context, task dto.TaskSummary) error {
+	ctx, cancel := context.WithTimeout(ctx, cs.taskTimeout)
+	defer cancel()
+
+	// dipanggil di luar consumer (backfill mode process) ctx belum membawa logger task
+	log := logger.FromContext(ctx, nil)
+	if log == nil {
+		correlationID := logger.CorrelationIDFromContext(ctx)
+		if correlationID == "" {
+			correlationID = uuid.NewString()
+		}
+
+		log = cs.logger.With(
+			zap.String("session_id", task.SessionID.String()),
+			zap.String("correlation_id", correlationID),
+		)
+		ctx = logger.WithCorrelationID(logger.WithContext(ctx, log), correlationID)
+	}
+
+	log.Info("received summary task", zap.Int("message_count", len(task.Messages)))
+
+	// tolak task yang timestamp pesannya tidak bisa diparsing agar histori tidak salah urut
+	_, validateSpan := tracing.Tracer().Start(ctx, constants.ENUM_QUEUE_SUMMARY_TASK+" validate")
+	if invalidIDs := invalidMessageTimestamps(task); len(invalidIDs) > 0 {
+		log.Error("task contains messages with unparseable timestamp", zap.Strings("message_ids", invalidIDs))
+		err := fmt.Errorf("%w: %s", dto.ErrInvalidMessageTimestamp, strings.Join(invalidIDs, ", "))
+		tracing.End(validateSpan, err)
+		return err
+	}
+	tracing.End(validateSpan, nil)
+
+	// panggil gRPC ke AI service untuk membuat ringkasan
+	aiStart := time.Now()
+	res, err := cs.grpcClient.GenerateSummary(ctx, buildSummaryRequest(task))
+	metrics.AIRequestDuration.WithLabelValues(status.Code(err).String()).Observe(time.Since(aiStart).Seconds())
+	if err != nil {
+		return fmt.Errorf("%w: %w", dto.ErrGenerateSummary, err)
+	}
+
+	log.Info("summary successfully generated")
+
+	content, err := protojson.Marshal(res)
+	if err != nil {
+		return fmt.Errorf("failed to encode summary: %w", err)
+	}
+
+	// semua perubahan satu task disimpan atomik: pesan, ringkasan, status sesi, notifikasi dan outbox event
+	ctx, persistSpan := tracing.Tracer().Start(ctx, constants.ENUM_QUEUE_SUMMARY_TASK+" persist")
+	dbStart := time.Now()
+	err = cs.txManager.WithTransaction(ctx, func(tx *gorm.DB) error {
+		if err := cs.consumerRepo.SaveMessages(ctx, tx, task); err != nil {
+			return fmt.Errorf("failed to save messages: %w", err)
+		}
+
+		summary := &entity.Summary{
+			Content:   content,
+			SessionID: task.SessionID,
+		}
+		if err := cs.consumerRepo.SaveSummary(ctx, tx, summary); err != nil {
+			return fmt.Errorf("failed to save summary: %w", err)
+		}
+
+		if err := cs.consumerRepo.UpdateSessionStatus(ctx, tx, task.SessionID,
+			[]entity.SessionStatus{entity.ONGOING, entity.PROCESSING_SUMMARY, entity.FINISHED},
+			entity.FINISHED,
+		); err != nil {
+			return fmt.Errorf("failed to update session status: %w", err)
+		}
+
+		notifications, err := cs.buildSummaryNotifications(ctx, tx, task)
+		if err != nil {
+			return fmt.Errorf("failed to get notification recipients: %w", err)
+		}
+		if err := cs.consumerRepo.CreateNotifications(ctx, tx, notifications); err != nil {
+			return fmt.Errorf("failed to create notifications: %w", err)
+		}
+
+		event, err := buildSummaryGeneratedEvent(ctx, summary)
+		if err != nil {
+			return fmt.Errorf("failed to build outbox event: %w", err)
+		}
+		if err := cs.outboxRepo.CreateOutboxEvents(ctx, tx, []entity.OutboxEvent{event}); err != nil {
+			return fmt.Errorf("failed to create outbox event: %w", err)
+		}
+
+		return nil
+	})
+	metrics.DBWriteDuration.WithLabelValues(constants.ENUM_DB_OPERATION_SAVE_TASK_RESULT, dbStatus(err)).Observe(time.Since(dbStart).Seconds())
+	tracing.End(persistSpan, err)
+	if err != nil {
+		return fmt.Errorf("%w: %w", dto.ErrPersistTaskResult, err)
+	}
+
+	cs.recordProcessed(task.SessionID)
+	log.Info("worker finished processing task")
+
+	return nil
+}
+
+func (cs *consumerService) buildSummaryNotifications(ctx context.Context, tx *gorm.DB, task dto.TaskSummary) ([]entity.Notification, error) {
+	lecturerIDs := make([]uuid.UUID, 0, len(task.Supervisors))
+	for _, sup := range task.Supervisors {
+		lecturerIDs = append(lecturerIDs, sup.ID)
+	}
+
+	recipients, err := cs.consumerRepo.GetNotificationRecipients(ctx, tx, task.Owner.ID, task.Student.ID, lecturerIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	// teks notifikasi diterjemahkan sesuai locale profil masing-masing penerima
+	params := map[string]any{"thesis_title": task.ThesisInfo.Title}
+	seen := make(map[uuid.UUID]bool, len(recipients))
+	var notifications []entity.Notification
+	for _, user := range recipients {
+		if user.ID == uuid.Nil || seen[user.ID] {
+			continue
+		}
+		seen[user.ID] = true
+
+		locale := i18n.Normalize(user.Locale)
+		if locale == "" {
+			locale = i18n.DefaultLocale
+		}
+
+		notifications = append(notifications, entity.Notification{
+			ID:      uuid.New(),
+			Title:   i18n.T(locale, dto.NOTIFICATION_TITLE_SUMMARY_READY),
+			Message: i18n.Tf(locale, dto.NOTIFICATION_MESSAGE_SUMMARY_READY, params),
+			UserID:  user.ID,
+		})
+	}
+
+	return notifications, nil
+}
+
+// buildSummaryGeneratedEvent menyimpan trace context task bersama event agar trace berlanjut
+// ke consumer event walaupun event baru di-publish relay outbox kemudian.
+func buildSummaryGeneratedEvent(ctx context.Context, summary *entity.Summary) (entity.OutboxEvent, error) {
+	payload, err := json.Marshal(dto.SummaryGeneratedEvent{
+		SessionID:   summary.SessionID,
+		SummaryID:   summary.ID,
+		Version:     summary.Version,
+		GeneratedAt: summary.CreatedAt,
+	})
+	if err != nil {
+		return entity.OutboxEvent{}, err
+	}
+
+	return entity.OutboxEvent{
+		ID:            uuid.New(),
+		EventType:     constants.ENUM_EVENT_SUMMARY_GENERATED,
+		AggregateID:   summary.SessionID,
+		Payload:       payload,
+		Headers:       tracing.InjectMap(ctx),
+		Status:        entity.OUTBOX_PENDING,
+		NextAttemptAt: time.Now(),
+	}, nil
+}
+
+// retryOrDeadLetter mengirim ulang task ke retry queue dengan backoff,
+// atau ke DLQ jika error permanen / jumlah retry sudah habis.
+func (cs *consumerService) retryOrDeadLetter(ctx context.Context, ch amqpPublisher, msg amqp.Delivery, taskID uuid.UUID, cause error) (bool, error) {
+	count := retryCount(msg)
+
+	headers := amqp.Table{}
+	for k, v := range msg.Headers {
+		headers[k] = v
+	}
+	headers[constants.ENUM_HEADER_RETRY_COUNT] = int32(count + 1)
+	headers[constants.ENUM_HEADER_LAST_ERROR] = cause.Error()
+	headers[constants.ENUM_HEADER_TASK_ID] = taskID.String()
+	// retry berikutnya menjadi anak dari span percobaan ini
+	tracing.InjectAMQP(ctx, headers)
+
+	publishing := amqp.Publishing{
+		ContentType:   msg.ContentType,
+		DeliveryMode:  amqp.Persistent,
+		MessageId:     msg.MessageId,
+		CorrelationId: correlationIDFromDelivery(msg, taskID),
+		Headers:       headers,
+		Body:          msg.Body,
+	}
+
+	queue := constants.ENUM_QUEUE_SUMMARY_TASK_RETRY
+	deadLetter := shouldDeadLetter(count, cause)
+	if deadLetter {
+		queue = constants.ENUM_QUEUE_SUMMARY_TASK_DLQ
+		logger.FromContext(ctx, cs.logger).Warn("dead-lettering summary task", zap.Error(cause))
+	} else {
+		delay := retryDelay(count)
+		publishing.Expiration = strconv.Itoa(delay)
+		logger.FromContext(ctx, cs.logger).Warn("scheduling summary task retry", zap.Int("next_retry_count", count+1), zap.Int("delay_ms", delay))
+	}
+
+	if err := ch.PublishWithContext(ctx, "", queue, false, false, publishing); err != nil {
+		return false, err
+	}
+
+	if deadLetter {
+		metrics.TasksDeadLettered.WithLabelValues(constants.ENUM_TASK_TYPE_SUMMARY, errorClass(cause)).Inc()
+	} else {
+		metrics.TasksRetried.WithLabelValues(constants.ENUM_TASK_TYPE_SUMMARY, errorClass(cause)).Inc()
+	}
+
+	return deadLetter, nil
+}
+
+// taskIDFromDelivery mengambil id task dari header, lalu MessageId. Jika keduanya kosong,
+// id diturunkan dari body agar redelivery pesan yang sama tetap tercatat di baris ledger yang sama.
+func taskIDFromDelivery(msg amqp.Delivery) uuid.UUID {
+	if v, ok := msg.Headers[constants.ENUM_HEADER_TASK_ID].(string); ok {
+		if id, err := uuid.Parse(v); err == nil {
+			return id
+		}
+	}
+
+	if id, err := uuid.Parse(msg.MessageId); err == nil {
+		return id
+	}
+
+	return uuid.NewSHA1(uuid.NameSpaceOID, msg.Body)
+}
+
+// correlationIDFromDelivery memakai CorrelationId dari publisher, lalu MessageId, lalu id task
+// agar task yang dikirim tanpa keduanya tetap punya id yang stabil antar retry.
+func correlationIDFromDelivery(msg amqp.Delivery, taskID uuid.UUID) string {
+	if msg.CorrelationId != "" {
+		return msg.CorrelationId
+	}
+	if msg.MessageId != "" {
+		return msg.MessageId
+	}
+
+	return taskID.String()
+}
+
+func retryCount(msg amqp.Delivery) int {
+	switch v := msg.Headers[constants.ENUM_HEADER_RETRY_COUNT].(type) {
+	case int32:
+		return int(v)
+	case int64:
+		return int(v)
+	case int:
+		return v
+	default:
+		return 0
+	}
+}
+
+// shouldDeadLetter: task dipindah ke DLQ jika error permanen atau sudah dicoba ulang sebanyak batas retry.
+func shouldDeadLetter(count int, cause error) bool {
+	return isPermanentTaskError(cause) || count >= constants.ENUM_TASK_MAX_RETRY
+}
+
+// retryDelay mengembalikan TTL (milidetik) di retry queue untuk percobaan ke-(count+1).
+func retryDelay(count int) int {
+	return constants.ENUM_TASK_RETRY_BASE_DELAY << count
+}
+
+// errorClass memetakan error ke himpunan label yang tetap agar cardinality metric terbatas.
+func errorClass(err error) string {
+	switch {
+	case err == nil:
+		return constants.ENUM_ERROR_CLASS_NONE
+	case errors.Is(err, dto.ErrInvalidTaskPayload):
+		return constants.ENUM_ERROR_CLASS_INVALID_PAYLOAD
+	case errors.Is(err, dto.ErrInvalidMessageTimestamp):
+		return constants.ENUM_ERROR_CLASS_INVALID_TIMESTAMP
+	case errors.Is(err, context.DeadlineExceeded):
+		return constants.ENUM_ERROR_CLASS_TIMEOUT
+	case errors.Is(err, context.Canceled):
+		return constants.ENUM_ERROR_CLASS_CANCELED
+	case errors.Is(err, dto.ErrGenerateSummary):
+		return constants.ENUM_ERROR_CLASS_AI_SERVICE
+	case errors.Is(err, dto.ErrPersistTaskResult):
+		return constants.ENUM_ERROR_CLASS_DATABASE
+	default:
+		return constants.ENUM_ERROR_CLASS_UNKNOWN
+	}
+}
+
+func dbStatus(err error) string {
+	if err != nil {
+		return constants.ENUM_TASK_STATUS_FAILED
+	}
+	return constants.ENUM_TASK_STATUS_SUCCEEDED
+}
+
+// error yang tidak akan berhasil walaupun dicoba ulang
+func isPermanentTaskError(err error) bool {
+	return errors.Is(err, dto.ErrInvalidTaskPayload) ||
+		errors.Is(err, dto.ErrInvalidMessageTimestamp) ||
+		// sesi yang belum dimulai atau sudah dihapus tidak akan berubah dengan retry
+		errors.Is(err, dto.ErrInvalidSessionStatusTransition) ||
+		errors.Is(err, dto.ErrNotFound)
+}
 
-			cs.logger.Info("received summary task",
-				zap.String("session_id", task.SessionID.String()),
-				zap.Int("message_count", len(task.Messages)),
-			)
-
-			// panggil gRPC ke AI service untuk membuat ringkasan
-			req := &pb.SummaryRequest{
-				Task: &pb.TaskSummary{
-					SessionId:     task.SessionID.String(),
-					SessionStatus: task.SessionStatus,
-					StartedAt:     task.StartedAt.String(),
-					EndedAt:       task.EndedAt.String(),
-					CreatedAt:     task.CreatedAt.String(),
-					Owner: &pb.CustomUser{
-						Id:         task.Owner.ID.String(),
-						Name:       task.Owner.Name,
-						Identifier: task.Owner.Identifier,
-						Role:       task.Owner.Role,
+func buildSummaryRequest(task dto.TaskSummary) *pb.SummaryRequest {
+	return &pb.SummaryRequest{
+		Task: &pb.TaskSummary{
+			SessionId:     task.SessionID.String(),
+			SessionStatus: task.SessionStatus,
+			StartedAt:     task.StartedAt.String(),
+			EndedAt:       task.EndedAt.String(),
+			CreatedAt:     task.CreatedAt.String(),
+			Owner: &pb.CustomUser{
+				Id:         task.Owner.ID.String(),
+				Name:       task.Owner.Name,
+				Identifier: task.Owner.Identifier,
+				Role:       task.Owner.Role,
+			},
+			Student: &pb.Student{
+				Id:    task.Student.ID.String(),
+				Nim:   task.Student.Nim,
+				Name:  task.Student.Name,
+				Email: task.Student.Email,
+				StudyProgram: &pb.StudyProgram{
+					Id:     task.Student.StudyProgram.ID.String(),
+					Name:   task.Student.StudyProgram.Name,
+					Degree: string(task.Student.StudyProgram.Degree),
+					Faculty: &pb.Faculty{
+						Id:   task.Student.StudyProgram.Faculty.ID.String(),
+						Name: task.Student.StudyProgram.Faculty.Name,
 					},
-					Student: &pb.Student{
-						Id:    task.Student.ID.String(),
-						Nim:   task.Student.Nim,
-						Name:  task.Student.Name,
-						Email: task.Student.Email,
+				},
+			},
+			Supervisors: func() []*pb.Lecturer {
+				var supervisors []*pb.Lecturer
+				for _, sup := range task.Supervisors {
+					supervisors = append(supervisors, &pb.Lecturer{
+						Id:           sup.ID.String(),
+						Nip:          sup.Nip,
+						Name:         sup.Name,
+						Email:        sup.Email,
+						TotalStudent: int32(sup.TotalStudent),
 						StudyProgram: &pb.StudyProgram{
-							Id:     task.Student.StudyProgram.ID.String(),
-							Name:   task.Student.StudyProgram.Name,
-							Degree: string(task.Student.StudyProgram.Degree),
+							Id:     sup.StudyProgram.ID.String(),
+							Name:   sup.StudyProgram.Name,
+							Degree: string(sup.StudyProgram.Degree),
 							Faculty: &pb.Faculty{
-								Id:   task.Student.StudyProgram.Faculty.ID.String(),
-								Name: task.Student.StudyProgram.Faculty.Name,
+								Id:   sup.StudyProgram.Faculty.ID.String(),
+								Name: sup.StudyProgram.Faculty.Name,
 							},
 						},
-					},
-					Supervisors: func() []*pb.Lecturer {
-						var supervisors []*pb.Lecturer
-						for _, sup := range task.Supervisors {
-							supervisors = append(supervisors, &pb.Lecturer{
-								Id:           sup.ID.String(),
-								Nip:          sup.Nip,
-								Name:         sup.Name,
-								Email:        sup.Email,
-								TotalStudent: int32(sup.TotalStudent),
-								StudyProgram: &pb.StudyProgram{
-									Id:     sup.StudyProgram.ID.String(),
-									Name:   sup.StudyProgram.Name,
-									Degree: string(sup.StudyProgram.Degree),
-									Faculty: &pb.Faculty{
-										Id:   sup.StudyProgram.Faculty.ID.String(),
-										Name: sup.StudyProgram.Faculty.Name,
-									},
-								},
-							})
-						}
-						return supervisors
-					}(),
-					ThesisInfo: &pb.ThesisInfo{
-						Title:       task.ThesisInfo.Title,
-						Progress:    string(task.ThesisInfo.Progress),
-						Description: task.ThesisInfo.Description,
-					},
-					Messages: func() []*pb.MessageSummary {
-						var messages []*pb.MessageSummary
-						for _, msg := range task.Messages {
-							messages = append(messages, &pb.MessageSummary{
-								Id:       msg.ID.String(),
-								IsText:   msg.IsText,
-								Text:     msg.Text,
-								FileUrl:  msg.FileURL,
-								FileType: msg.FileType,
-								Sender: &pb.CustomUser{
-									Id:         msg.Sender.ID.String(),
-									Name:       msg.Sender.Name,
-									Identifier: msg.Sender.Identifier,
-									Role:       msg.Sender.Role,
-								},
-								ParentMessageId: func() string {
-									if msg.ParentMessageID != nil {
-										return msg.ParentMessageID.String()
-									}
-									return ""
-								}(),
-								Timestamp: msg.Timestamp,
-							})
-						}
-						return messages
-					}(),
-				},
-			}
-
-			_, err := cs.grpcClient.GenerateSummary(ctx, req)
-			if err != nil {
-				cs.logger.Error("failed to generate summary via gRPC", zap.Error(err))
-				continue
-			}
-
-			cs.logger.Info("summary successfully generated",
-				zap.String("session_id", task.SessionID.String()),
-			)
+					})
+				}
+				return supervisors
+			}(),
+			ThesisInfo: &pb.ThesisInfo{
+				Title:       task.ThesisInfo.Title,
+				Progress:    string(task.ThesisInfo.Progress),
+				Description: task.ThesisInfo.Description,
+			},
+			Messages: func() []*pb.MessageSummary {
+				var messages []*pb.MessageSummary
+				for _, msg := range task.Messages {
+					messages = append(messages, &pb.MessageSummary{
+						Id:       msg.ID.String(),
+						IsText:   msg.IsText,
+						Text:     msg.Text,
+						FileUrl:  msg.FileURL,
+						FileType: msg.FileType,
+						Sender: &pb.CustomUser{
+							Id:         msg.Sender.ID.String(),
+							Name:       msg.Sender.Name,
+							Identifier: msg.Sender.Identifier,
+							Role:       msg.Sender.Role,
+						},
+						ParentMessageId: func() string {
+							if msg.ParentMessageID != nil {
+								return msg.ParentMessageID.String()
+							}
+							return ""
+						}(),
+						Timestamp: msg.Timestamp,
+					})
+				}
+				return messages
+			}(),
+		},
+	}
+}
 
-			if err := cs.consumerRepo.SaveMessages(ctx, nil, task); err != nil {
-				cs.logger.Error("failed to save messages to DB", zap.Error(err))
-				continue
-			}
+func invalidMessageTimestamps(task dto.TaskSummary) []string {
+	loc := helper.DefaultLocation()
 
-			cs.logger.Info("worker finished processing task",
-				zap.String("session_id", task.SessionID.String()),
-			)
+	var invalidIDs []string
+	for _, m := range task.Messages {
+		if _, err := helper.ParseTimestamp(m.Timestamp, loc); err != nil {
+			invalidIDs = append(invalidIDs, m.ID.String())
 		}
 	}
+
+	return invalidIDs
 }
@@ -0,0 +1,513 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"maps"
+	"testing"
+	"time"
+
+	pb "github.com/Amierza/ai-service/proto"
+	"github.com/Amierza/worker-service/constants"
+	"github.com/Amierza/worker-service/dto"
+	"github.com/Amierza/worker-service/entity"
+	"github.com/Amierza/worker-service/internal/testdb"
+	"github.com/Amierza/worker-service/repository"
+	"github.com/google/uuid"
+	amqp "github.com/rabbitmq/amqp091-go"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+func TestRetryCount(t *testing.T) {
+	tests := []struct {
+		name    string
+		headers amqp.Table
+		want    int
+	}{
+		{name: "no headers", headers: nil, want: 0},
+		{name: "int32", headers: amqp.Table{constants.ENUM_HEADER_RETRY_COUNT: int32(2)}, want: 2},
+		{name: "int64", headers: amqp.Table{constants.ENUM_HEADER_RETRY_COUNT: int64(3)}, want: 3},
+		{name: "int", headers: amqp.Table{constants.ENUM_HEADER_RETRY_COUNT: 1}, want: 1},
+		{name: "unexpected type", headers: amqp.Table{constants.ENUM_HEADER_RETRY_COUNT: "2"}, want: 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := retryCount(amqp.Delivery{Headers: tt.headers}); got != tt.want {
+				t.Errorf("retryCount() = %d, want %d", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestShouldDeadLetter(t *testing.T) {
+	transient := errors.New("grpc unavailable")
+
+	tests := []struct {
+		name  string
+		count int
+		cause error
+		want  bool
+	}{
+		{name: "transient first failure", count: 0, cause: transient, want: false},
+		{name: "transient below limit", count: constants.ENUM_TASK_MAX_RETRY - 1, cause: transient, want: false},
+		{name: "transient retries exhausted", count: constants.ENUM_TASK_MAX_RETRY, cause: transient, want: true},
+		{name: "invalid payload", count: 0, cause: fmt.Errorf("%w: unexpected EOF", dto.ErrInvalidTaskPayload), want: true},
+		{name: "invalid timestamp", count: 0, cause: fmt.Errorf("%w: m1", dto.ErrInvalidMessageTimestamp), want: true},
+		{name: "session not started", count: 0, cause: fmt.Errorf("%w: %w", dto.ErrPersistTaskResult, dto.ErrInvalidSessionStatusTransition), want: true},
+		{name: "session deleted", count: 0, cause: fmt.Errorf("%w: %w", dto.ErrPersistTaskResult, dto.ErrNotFound), want: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := shouldDeadLetter(tt.count, tt.cause); got != tt.want {
+				t.Errorf("shouldDeadLetter(%d, %v) = %v, want %v", tt.count, tt.cause, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRetryDelay(t *testing.T) {
+	base := constants.ENUM_TASK_RETRY_BASE_DELAY
+
+	tests := []struct {
+		count int
+		want  int
+	}{
+		{count: 0, want: base},
+		{count: 1, want: base * 2},
+		{count: 2, want: base * 4},
+	}
+
+	for _, tt := range tests {
+		t.Run(fmt.Sprintf("retry %d", tt.count), func(t *testing.T) {
+			if got := retryDelay(tt.count); got != tt.want {
+				t.Errorf("retryDelay(%d) = %d, want %d", tt.count, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSummaryQueuesRouteRetryBackToMainQueue(t *testing.T) {
+	queues := make(map[string]amqp.Table)
+	for _, q := range summaryQueues() {
+		queues[q.name] = q.args
+	}
+
+	for _, name := range []string{
+		constants.ENUM_QUEUE_SUMMARY_TASK,
+		constants.ENUM_QUEUE_SUMMARY_TASK_RETRY,
+		constants.ENUM_QUEUE_SUMMARY_TASK_DLQ,
+	} {
+		if _, ok := queues[name]; !ok {
+			t.Fatalf("queue %s is not declared", name)
+		}
+	}
+
+	retry := queues[constants.ENUM_QUEUE_SUMMARY_TASK_RETRY]
+	if got := retry["x-dead-letter-exchange"]; got != "" {
+		t.Errorf("retry queue dead-letter exchange = %v, want default exchange", got)
+	}
+	if got := retry["x-dead-letter-routing-key"]; got != constants.ENUM_QUEUE_SUMMARY_TASK {
+		t.Errorf("retry queue dead-letter routing key = %v, want %s", got, constants.ENUM_QUEUE_SUMMARY_TASK)
+	}
+
+	if len(queues[constants.ENUM_QUEUE_SUMMARY_TASK_DLQ]) != 0 {
+		t.Errorf("dlq must not dead-letter anywhere, got args %v", queues[constants.ENUM_QUEUE_SUMMARY_TASK_DLQ])
+	}
+}
+
+var errInjected = errors.New("injected failure")
+
+type fakeSummaryClient struct{}
+
+func (fakeSummaryClient) GenerateSummary(context.Context, *pb.SummaryRequest) (*pb.SummaryResponse, error) {
+	return &pb.SummaryResponse{}, nil
+}
+
+// failingConsumerRepo menjalankan repository asli lalu mengembalikan error pada step failOn,
+// sehingga write yang sudah terjadi di transaksi harus di-rollback.
+type failingConsumerRepo struct {
+	repository.IConsumerRepository
+	failOn string
+}
+
+func (r *failingConsumerRepo) SaveSummary(ctx context.Context, tx *gorm.DB, summary *entity.Summary) error {
+	if err := r.IConsumerRepository.SaveSummary(ctx, tx, summary); err != nil {
+		return err
+	}
+	if r.failOn == "SaveSummary" {
+		return errInjected
+	}
+	return nil
+}
+
+func (r *failingConsumerRepo) UpdateSessionStatus(ctx context.Context, tx *gorm.DB, sessionID uuid.UUID, from []entity.SessionStatus, to entity.SessionStatus) error {
+	if err := r.IConsumerRepository.UpdateSessionStatus(ctx, tx, sessionID, from, to); err != nil {
+		return err
+	}
+	if r.failOn == "UpdateSessionStatus" {
+		return errInjected
+	}
+	return nil
+}
+
+func (r *failingConsumerRepo) CreateNotifications(ctx context.Context, tx *gorm.DB, notifications []entity.Notification) error {
+	if err := r.IConsumerRepository.CreateNotifications(ctx, tx, notifications); err != nil {
+		return err
+	}
+	if r.failOn == "CreateNotifications" {
+		return errInjected
+	}
+	return nil
+}
+
+type failingOutboxRepo struct {
+	repository.IOutboxRepository
+	fail bool
+}
+
+func (r *failingOutboxRepo) CreateOutboxEvents(ctx context.Context, tx *gorm.DB, events []entity.OutboxEvent) error {
+	if err := r.IOutboxRepository.CreateOutboxEvents(ctx, tx, events); err != nil {
+		return err
+	}
+	if r.fail {
+		return errInjected
+	}
+	return nil
+}
+
+type consumerFixture struct {
+	db      *gorm.DB
+	service *consumerService
+	task    dto.TaskSummary
+}
+
+func newConsumerFixture(t *testing.T, failOn string) consumerFixture {
+	t.Helper()
+
+	db := testdb.New(t,
+		&entity.User{}, &entity.Session{}, &entity.Message{}, &entity.Summary{},
+		&entity.Notification{}, &entity.OutboxEvent{}, &entity.TaskLedger{},
+	)
+
+	studentID := uuid.New()
+	owner := entity.User{ID: uuid.New(), Identifier: "198501012010011001", Role: entity.LECTURER, Locale: "id"}
+	student := entity.User{ID: uuid.New(), Identifier: "5025201001", Role: entity.STUDENT, Locale: "en", StudentID: &studentID}
+	session := entity.Session{ID: uuid.New(), Status: entity.PROCESSING_SUMMARY, UserIDOwner: owner.ID}
+	for _, row := range []any{&owner, &student, &session} {
+		if err := db.Create(row).Error; err != nil {
+			t.Fatalf("failed to seed %T: %v", row, err)
+		}
+	}
+
+	startedAt, endedAt := time.Date(2024, 3, 5, 7, 0, 0, 0, time.UTC), time.Date(2024, 3, 5, 8, 0, 0, 0, time.UTC)
+	task := dto.TaskSummary{
+		SessionID: session.ID,
+		StartedAt: &startedAt,
+		EndedAt:   &endedAt,
+		Owner:     dto.CustomUserResponse{ID: owner.ID, Role: string(entity.LECTURER)},
+		Student:   dto.StudentResponse{ID: studentID},
+		ThesisInfo: dto.ThesisSummary{
+			Title: "Deteksi Plagiarisme Skripsi",
+		},
+		Messages: []dto.MessageSummary{
+			{ID: uuid.New(), IsText: true, Text: "bab 1 sudah saya kirim", Sender: dto.CustomUserResponse{ID: student.ID, Role: string(entity.STUDENT)}, Timestamp: "2024-03-05T14:30:15+07:00"},
+			{ID: uuid.New(), IsText: true, Text: "baik, saya cek", Sender: dto.CustomUserResponse{ID: owner.ID, Role: string(entity.LECTURER)}, Timestamp: "2024-03-05T14:31:00+07:00"},
+		},
+	}
+
+	consumerRepo := &failingConsumerRepo{IConsumerRepository: repository.NewConsumerRepository(db), failOn: failOn}
+	outboxRepo := &failingOutboxRepo{IOutboxRepository: repository.NewOutboxRepository(db), fail: failOn == "CreateOutboxEvents"}
+	cs := NewConsumerService(consumerRepo, outboxRepo, repository.NewTaskRepository(db), repository.NewTransactionManager(db), zap.NewNop(), nil, nil, fakeSummaryClient{}, time.Minute)
+
+	return consumerFixture{db: db, service: cs, task: task}
+}
+
+// assertNothingPersisted memastikan tidak ada hasil task yang tersisa setelah rollback
+// dan status sesi tetap wantStatus.
+func (f consumerFixture) assertNothingPersisted(t *testing.T, wantStatus entity.SessionStatus) {
+	t.Helper()
+
+	for _, model := range []any{&entity.Message{}, &entity.Summary{}, &entity.Notification{}, &entity.OutboxEvent{}} {
+		var count int64
+		if err := f.db.Model(model).Count(&count).Error; err != nil {
+			t.Fatalf("count %T: %v", model, err)
+		}
+		if count != 0 {
+			t.Errorf("%T rows = %d after failed transaction, want 0", model, count)
+		}
+	}
+
+	var session entity.Session
+	if err := f.db.Unscoped().First(&session, "id = ?", f.task.SessionID).Error; err != nil {
+		t.Fatalf("load session: %v", err)
+	}
+	if session.Status != wantStatus {
+		t.Errorf("session status = %s after failed transaction, want %s", session.Status, wantStatus)
+	}
+}
+
+func TestProcessSummaryTaskCommitsAllWrites(t *testing.T) {
+	f := newConsumerFixture(t, "")
+
+	if err := f.service.ProcessSummaryTask(context.Background(), f.task); err != nil {
+		t.Fatalf("ProcessSummaryTask: %v", err)
+	}
+
+	counts := map[string]struct {
+		model any
+		want  int64
+	}{
+		"messages":      {model: &entity.Message{}, want: 2},
+		"summaries":     {model: &entity.Summary{}, want: 1},
+		"notifications": {model: &entity.Notification{}, want: 2},
+		"outbox events": {model: &entity.OutboxEvent{}, want: 1},
+	}
+	for name, c := range counts {
+		var got int64
+		if err := f.db.Model(c.model).Count(&got).Error; err != nil {
+			t.Fatalf("count %s: %v", name, err)
+		}
+		if got != c.want {
+			t.Errorf("%s = %d, want %d", name, got, c.want)
+		}
+	}
+
+	var session entity.Session
+	if err := f.db.First(&session, "id = ?", f.task.SessionID).Error; err != nil {
+		t.Fatalf("load session: %v", err)
+	}
+	if session.Status != entity.FINISHED {
+		t.Errorf("session status = %s, want %s", session.Status, entity.FINISHED)
+	}
+}
+
+func TestProcessSummaryTaskRollsBackOnFailure(t *testing.T) {
+	tests := []struct {
+		name          string
+		failOn        string
+		sessionStatus entity.SessionStatus
+		deleteSession bool
+		wantErr       error
+	}{
+		{name: "save summary fails", failOn: "SaveSummary", wantErr: errInjected},
+		{name: "update session status fails", failOn: "UpdateSessionStatus", wantErr: errInjected},
+		// sesi yang belum dimulai tidak boleh langsung selesai
+		{name: "session status transition rejected", sessionStatus: entity.WAITING, wantErr: dto.ErrInvalidSessionStatusTransition},
+		{name: "session deleted", deleteSession: true, wantErr: dto.ErrNotFound},
+		{name: "create notifications fails", failOn: "CreateNotifications", wantErr: errInjected},
+		{name: "outbox insert fails", failOn: "CreateOutboxEvents", wantErr: errInjected},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f := newConsumerFixture(t, tt.failOn)
+			wantStatus := entity.PROCESSING_SUMMARY
+			if tt.sessionStatus != "" {
+				wantStatus = tt.sessionStatus
+				if err := f.db.Model(&entity.Session{}).Where("id = ?", f.task.SessionID).Update("status", wantStatus).Error; err != nil {
+					t.Fatalf("update session: %v", err)
+				}
+			}
+
+			if tt.deleteSession {
+				if err := f.db.Delete(&entity.Session{}, "id = ?", f.task.SessionID).Error; err != nil {
+					t.Fatalf("delete session: %v", err)
+				}
+			}
+
+			err := f.service.ProcessSummaryTask(context.Background(), f.task)
+			if !errors.Is(err, dto.ErrPersistTaskResult) {
+				t.Errorf("error = %v, want ErrPersistTaskResult", err)
+			}
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("error = %v, want %v", err, tt.wantErr)
+			}
+
+			f.assertNothingPersisted(t, wantStatus)
+		})
+	}
+}
+
+// deliveryRecorder mencatat urutan publish / ack / nack untuk satu delivery.
+type deliveryRecorder struct {
+	events     []string
+	publishErr error
+}
+
+func (r *deliveryRecorder) PublishWithContext(_ context.Context, _, key string, _, _ bool, _ amqp.Publishing) error {
+	if r.publishErr != nil {
+		r.events = append(r.events, "publish-failed:"+key)
+		return r.publishErr
+	}
+	r.events = append(r.events, "publish:"+key)
+	return nil
+}
+
+func (r *deliveryRecorder) Ack(uint64, bool) error {
+	r.events = append(r.events, "ack")
+	return nil
+}
+
+func (r *deliveryRecorder) Nack(_ uint64, _ bool, requeue bool) error {
+	r.events = append(r.events, fmt.Sprintf("nack:requeue=%v", requeue))
+	return nil
+}
+
+func (r *deliveryRecorder) Reject(_ uint64, requeue bool) error {
+	r.events = append(r.events, fmt.Sprintf("reject:requeue=%v", requeue))
+	return nil
+}
+
+func TestHandleDeliveryAcksOnlyAfterCommitOrRepublish(t *testing.T) {
+	tests := []struct {
+		name       string
+		failOn     string
+		body       func(task dto.TaskSummary) []byte
+		publishErr error
+		wantEvents []string
+		wantRows   bool
+	}{
+		{
+			name:       "committed task is acked",
+			wantEvents: []string{"ack"},
+			wantRows:   true,
+		},
+		{
+			name:       "failed transaction is moved to retry queue before ack",
+			failOn:     "CreateNotifications",
+			wantEvents: []string{"publish:" + constants.ENUM_QUEUE_SUMMARY_TASK_RETRY, "ack"},
+		},
+		{
+			name:       "failed transaction is not acked when republish fails",
+			failOn:     "CreateOutboxEvents",
+			publishErr: errors.New("channel closed"),
+			wantEvents: []string{"publish-failed:" + constants.ENUM_QUEUE_SUMMARY_TASK_RETRY, "nack:requeue=true"},
+		},
+		{
+			name:       "invalid payload is dead-lettered",
+			body:       func(dto.TaskSummary) []byte { return []byte("{not json") },
+			wantEvents: []string{"publish:" + constants.ENUM_QUEUE_SUMMARY_TASK_DLQ, "ack"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f := newConsumerFixture(t, tt.failOn)
+
+			body, err := json.Marshal(f.task)
+			if err != nil {
+				t.Fatalf("marshal task: %v", err)
+			}
+			if tt.body != nil {
+				body = tt.body(f.task)
+			}
+
+			rec := &deliveryRecorder{publishErr: tt.publishErr}
+			f.service.handleDelivery(context.Background(), rec, amqp.Delivery{
+				Acknowledger: rec,
+				DeliveryTag:  1,
+				MessageId:    uuid.NewString(),
+				ContentType:  "application/json",
+				Body:         body,
+			})
+
+			if fmt.Sprint(rec.events) != fmt.Sprint(tt.wantEvents) {
+				t.Errorf("delivery events = %v, want %v", rec.events, tt.wantEvents)
+			}
+
+			if !tt.wantRows {
+				f.assertNothingPersisted(t, entity.PROCESSING_SUMMARY)
+			}
+		})
+	}
+}
+
+func TestSummaryGeneratedEventCarriesTraceContext(t *testing.T) {
+	previous := otel.GetTextMapPropagator()
+	otel.SetTextMapPropagator(propagation.TraceContext{})
+	t.Cleanup(func() { otel.SetTextMapPropagator(previous) })
+
+	traceID, _ := trace.TraceIDFromHex("4bf92f3577b34da6a3ce929d0e0e4736")
+	spanID, _ := trace.SpanIDFromHex("00f067aa0ba902b7")
+	traced := trace.ContextWithSpanContext(context.Background(), trace.NewSpanContext(trace.SpanContextConfig{
+		TraceID:    traceID,
+		SpanID:     spanID,
+		TraceFlags: trace.FlagsSampled,
+	}))
+
+	tests := []struct {
+		name        string
+		ctx         context.Context
+		wantHeaders map[string]string
+	}{
+		{name: "traced task", ctx: traced, wantHeaders: map[string]string{"traceparent": "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01"}},
+		{name: "untraced task", ctx: context.Background()},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db := testdb.New(t, &entity.OutboxEvent{})
+			outboxRepo := repository.NewOutboxRepository(db)
+
+			event, err := buildSummaryGeneratedEvent(tt.ctx, &entity.Summary{ID: uuid.New(), SessionID: uuid.New(), Version: 1})
+			if err != nil {
+				t.Fatalf("buildSummaryGeneratedEvent: %v", err)
+			}
+			if err := outboxRepo.CreateOutboxEvents(context.Background(), nil, []entity.OutboxEvent{event}); err != nil {
+				t.Fatalf("CreateOutboxEvents: %v", err)
+			}
+
+			// header harus tetap ada setelah dibaca ulang oleh relay
+			events, err := outboxRepo.GetPendingOutboxEventsForUpdate(context.Background(), db, 10)
+			if err != nil {
+				t.Fatalf("GetPendingOutboxEventsForUpdate: %v", err)
+			}
+			if len(events) != 1 {
+				t.Fatalf("got %d pending events, want 1", len(events))
+			}
+			if got := events[0].Headers; !maps.Equal(got, tt.wantHeaders) {
+				t.Errorf("headers = %v, want %v", got, tt.wantHeaders)
+			}
+		})
+	}
+}
+
+// hangingSummaryClient meniru AI service yang tidak pernah membalas.
+type hangingSummaryClient struct{}
+
+func (hangingSummaryClient) GenerateSummary(ctx context.Context, _ *pb.SummaryRequest) (*pb.SummaryResponse, error) {
+	<-ctx.Done()
+	return nil, ctx.Err()
+}
+
+func TestProcessSummaryTaskTimesOutHungAIService(t *testing.T) {
+	f := newConsumerFixture(t, "")
+	f.service.grpcClient = hangingSummaryClient{}
+	f.service.taskTimeout = 50 * time.Millisecond
+
+	// ctx pemanggil tidak pernah dibatalkan, seperti context.WithoutCancel di loop consumer
+	done := make(chan error, 1)
+	go func() { done <- f.service.ProcessSummaryTask(context.Background(), f.task) }()
+
+	select {
+	case err := <-done:
+		if !errors.Is(err, dto.ErrGenerateSummary) || !errors.Is(err, context.DeadlineExceeded) {
+			t.Fatalf("error = %v, want ErrGenerateSummary wrapping DeadlineExceeded", err)
+		}
+		if shouldDeadLetter(0, err) {
+			t.Error("timed out task should be retried, not dead-lettered")
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("ProcessSummaryTask did not return after task timeout")
+	}
+
+	f.assertNothingPersisted(t, entity.PROCESSING_SUMMARY)
+}
@@ -0,0 +1,160 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/Amierza/worker-service/constants"
+	"github.com/Amierza/worker-service/dto"
+	grpcclient "github.com/Amierza/worker-service/grpc_client"
+	"github.com/Amierza/worker-service/repository"
+	amqp "github.com/rabbitmq/amqp091-go"
+	"google.golang.org/grpc/connectivity"
+)
+
+type (
+	IHealthService interface {
+		Liveness(ctx context.Context) (dto.HealthResponse, bool)
+		Readiness(ctx context.Context) (dto.HealthResponse, bool)
+	}
+
+	healthService struct {
+		healthRepo                repository.IHealthRepository
+		consumerService           IConsumerService
+		consumerControllerService IConsumerControllerService
+		rabbitmq                  *amqp.Connection
+		grpcClient                *grpcclient.SummaryClient
+		startedAt                 time.Time
+	}
+)
+
+// NewHealthService; consumerService, consumerControllerService & grpcClient boleh nil pada mode
+// yang tidak menjalankan consumer (mode api), pengecekannya dilewati.
+func NewHealthService(healthRepo repository.IHealthRepository, consumerService IConsumerService, consumerControllerService IConsumerControllerService, rabbitmq *amqp.Connection, grpcClient *grpcclient.SummaryClient) *healthService {
+	return &healthService{
+		healthRepo:                healthRepo,
+		consumerService:           consumerService,
+		consumerControllerService: consumerControllerService,
+		rabbitmq:                  rabbitmq,
+		grpcClient:                grpcClient,
+		startedAt:                 time.Now(),
+	}
+}
+
+// Liveness tidak memeriksa dependency supaya gangguan dependency tidak membuat kubernetes
+// me-restart pod. Pengecualiannya koneksi RabbitMQ pada mode worker: koneksi tidak di-dial
+// ulang setelah broker restart, jadi pod perlu di-restart agar consumer bisa pulih.
+func (hs *healthService) Liveness(ctx context.Context) (dto.HealthResponse, bool) {
+	res := dto.HealthResponse{
+		Status:        constants.ENUM_HEALTH_STATUS_UP,
+		UptimeSeconds: int64(time.Since(hs.startedAt).Seconds()),
+	}
+
+	if hs.consumerService == nil {
+		return res, true
+	}
+
+	check := runHealthCheck("rabbitmq_connection", func() error {
+		if hs.rabbitmq == nil || hs.rabbitmq.IsClosed() {
+			return errors.New("connection closed")
+		}
+		return nil
+	})
+	res.Checks = []dto.DependencyHealth{check}
+	if check.Status != constants.ENUM_HEALTH_STATUS_UP {
+		res.Status = constants.ENUM_HEALTH_STATUS_DOWN
+		return res, false
+	}
+
+	return res, true
+}
+
+// Readiness memeriksa semua dependency; ready hanya jika semuanya up.
+func (hs *healthService) Readiness(ctx context.Context) (dto.HealthResponse, bool) {
+	ctx, cancel := context.WithTimeout(ctx, constants.ENUM_HEALTH_CHECK_TIMEOUT*time.Millisecond)
+	defer cancel()
+
+	checks := []dto.DependencyHealth{
+		runHealthCheck("postgres", func() error {
+			return hs.healthRepo.Ping(ctx)
+		}),
+		runHealthCheck("rabbitmq_connection", func() error {
+			if hs.rabbitmq == nil || hs.rabbitmq.IsClosed() {
+				return errors.New("connection closed")
+			}
+			return nil
+		}),
+	}
+
+	if hs.grpcClient != nil {
+		checks = append(checks, runHealthCheck("ai_service_grpc", func() error {
+			// idle = belum ada RPC (lazy connect), masih dianggap sehat
+			state := hs.grpcClient.State()
+			if state != connectivity.Ready && state != connectivity.Idle {
+				return fmt.Errorf("connection state %s", state)
+			}
+			return nil
+		}))
+	}
+
+	var consumerState string
+	if hs.consumerControllerService != nil && hs.consumerService != nil {
+		// consumer yang sengaja di-pause / stop lewat control API tidak dianggap gagal
+		consumerState = hs.consumerControllerService.Status(ctx).State
+		consumerActive := consumerState == constants.ENUM_CONSUMER_STATE_RUNNING
+
+		checks = append(checks,
+			runHealthCheck("rabbitmq_channel", func() error {
+				if consumerActive && !hs.consumerService.IsChannelOpen() {
+					return errors.New("consumer channel closed")
+				}
+				return nil
+			}),
+			runHealthCheck("consumer", func() error {
+				if consumerActive && !hs.consumerService.IsRunning() {
+					return errors.New("consumer loop is not running")
+				}
+				return nil
+			}),
+		)
+	}
+
+	ready := true
+	for _, check := range checks {
+		if check.Status != constants.ENUM_HEALTH_STATUS_UP {
+			ready = false
+			break
+		}
+	}
+
+	res := dto.HealthResponse{
+		Status:        constants.ENUM_HEALTH_STATUS_UP,
+		UptimeSeconds: int64(time.Since(hs.startedAt).Seconds()),
+		Checks:        checks,
+		ConsumerState: consumerState,
+	}
+	if !ready {
+		res.Status = constants.ENUM_HEALTH_STATUS_DOWN
+	}
+
+	return res, ready
+}
+
+func runHealthCheck(name string, check func() error) dto.DependencyHealth {
+	start := time.Now()
+	err := check()
+
+	res := dto.DependencyHealth{
+		Name:      name,
+		Status:    constants.ENUM_HEALTH_STATUS_UP,
+		LatencyMs: float64(time.Since(start).Microseconds()) / 1000,
+	}
+	if err != nil {
+		res.Status = constants.ENUM_HEALTH_STATUS_DOWN
+		res.Error = err.Error()
+	}
+
+	return res
+}
@@ -0,0 +1,40 @@
+package service
+
+import (
+	"context"
+	"testing"
+
+	"github.com/Amierza/worker-service/constants"
+)
+
+func TestLivenessFailsOnlyForWorkerWithoutConnection(t *testing.T) {
+	tests := []struct {
+		name            string
+		consumerService IConsumerService
+		wantAlive       bool
+	}{
+		// mode api tidak bergantung pada koneksi RabbitMQ untuk tetap hidup
+		{name: "api mode", consumerService: nil, wantAlive: true},
+		// koneksi tidak di-dial ulang, pod harus di-restart
+		{name: "worker mode connection closed", consumerService: newBlockingConsumer(), wantAlive: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			hs := NewHealthService(nil, tt.consumerService, nil, nil, nil)
+
+			res, alive := hs.Liveness(context.Background())
+			if alive != tt.wantAlive {
+				t.Fatalf("alive = %v, want %v", alive, tt.wantAlive)
+			}
+
+			wantStatus := constants.ENUM_HEALTH_STATUS_UP
+			if !tt.wantAlive {
+				wantStatus = constants.ENUM_HEALTH_STATUS_DOWN
+			}
+			if res.Status != wantStatus {
+				t.Fatalf("status = %q, want %q", res.Status, wantStatus)
+			}
+		})
+	}
+}
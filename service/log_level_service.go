@@ -0,0 +1,52 @@
+package service
+
+import (
+	"context"
+
+	"github.com/Amierza/worker-service/dto"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+type (
+	ILogLevelService interface {
+		GetLogLevel(ctx context.Context) dto.LogLevelResponse
+		SetLogLevel(ctx context.Context, req dto.UpdateLogLevelRequest) (dto.LogLevelResponse, error)
+	}
+
+	logLevelService struct {
+		level  zap.AtomicLevel
+		logger *zap.Logger
+	}
+)
+
+// NewLogLevelService; level adalah AtomicLevel yang sama dengan logger aplikasi, sehingga
+// perubahan langsung berlaku tanpa restart. Perubahan hanya berlaku untuk proses ini.
+func NewLogLevelService(level zap.AtomicLevel, logger *zap.Logger) *logLevelService {
+	return &logLevelService{
+		level:  level,
+		logger: logger,
+	}
+}
+
+func (ls *logLevelService) GetLogLevel(ctx context.Context) dto.LogLevelResponse {
+	return dto.LogLevelResponse{Level: ls.level.String()}
+}
+
+func (ls *logLevelService) SetLogLevel(ctx context.Context, req dto.UpdateLogLevelRequest) (dto.LogLevelResponse, error) {
+	level, err := zapcore.ParseLevel(req.Level)
+	if err != nil {
+		return dto.LogLevelResponse{}, dto.ErrInvalidLogLevel
+	}
+
+	previous := ls.level.Level()
+	ls.level.SetLevel(level)
+
+	// dicatat di warn agar tetap terlihat walaupun level baru lebih tinggi dari info
+	ls.logger.Warn("log level changed",
+		zap.String("previous_level", previous.String()),
+		zap.String("level", level.String()),
+	)
+
+	return dto.LogLevelResponse{Level: level.String()}, nil
+}
@@ -0,0 +1,247 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/Amierza/worker-service/constants"
+	"github.com/Amierza/worker-service/entity"
+	"github.com/Amierza/worker-service/helper"
+	"github.com/Amierza/worker-service/metrics"
+	"github.com/Amierza/worker-service/repository"
+	"github.com/Amierza/worker-service/tracing"
+	amqp "github.com/rabbitmq/amqp091-go"
+	semconv "go.opentelemetry.io/otel/semconv/v1.37.0"
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+type (
+	IOutboxService interface {
+		RelayOutboxEvents(ctx context.Context) error
+	}
+
+	outboxService struct {
+		outboxRepo repository.IOutboxRepository
+		txManager  repository.ITransactionManager
+		logger     *zap.Logger
+		rabbitmq   *amqp.Connection
+	}
+
+	// outboxPublisher dipenuhi confirmChannel; relayBatch hanya butuh cek channel & publish event.
+	outboxPublisher interface {
+		IsClosed() bool
+		PublishEvent(ctx context.Context, event entity.OutboxEvent) error
+	}
+
+	// confirmChannel adalah channel AMQP mode publisher confirm milik relay.
+	confirmChannel struct {
+		*amqp.Channel
+	}
+)
+
+func NewOutboxService(outboxRepo repository.IOutboxRepository, txManager repository.ITransactionManager, logger *zap.Logger, rabbitmq *amqp.Connection) *outboxService {
+	return &outboxService{
+		outboxRepo: outboxRepo,
+		txManager:  txManager,
+		logger:     logger,
+		rabbitmq:   rabbitmq,
+	}
+}
+
+// RelayOutboxEvents mem-publish event pending dari tabel outbox ke RabbitMQ
+// dengan publisher confirm, lalu menandainya sent di transaksi yang sama.
+// Jika channel tertutup (broker restart / gangguan jaringan) channel dibuka ulang
+// dengan backoff; relay hanya berhenti ketika ctx selesai. Backoff kembali ke minimum
+// setiap kali channel berhasil dibuka.
+func (obs *outboxService) RelayOutboxEvents(ctx context.Context) error {
+	minBackoff := constants.ENUM_OUTBOX_RELAY_RESTART_MIN_BACKOFF * time.Millisecond
+	backoff := minBackoff
+	for {
+		err := obs.relay(ctx, func() {
+			backoff = minBackoff
+		})
+		if ctx.Err() != nil {
+			obs.logger.Info("outbox relay stopped by context")
+			return nil
+		}
+
+		obs.logger.Error("outbox relay stopped, reopening channel", zap.Error(err), zap.Duration("backoff", backoff))
+
+		select {
+		case <-ctx.Done():
+			obs.logger.Info("outbox relay stopped by context")
+			return nil
+		case <-time.After(backoff):
+		}
+
+		backoff *= 2
+		if maxBackoff := constants.ENUM_OUTBOX_RELAY_RESTART_MAX_BACKOFF * time.Millisecond; backoff > maxBackoff {
+			backoff = maxBackoff
+		}
+	}
+}
+
+// relay membuka satu channel confirm dan mem-poll outbox sampai channel tertutup atau ctx selesai.
+// connected dipanggil sekali setelah channel & exchange siap.
+func (obs *outboxService) relay(ctx context.Context, connected func()) error {
+	ch, err := obs.rabbitmq.Channel()
+	if err != nil {
+		return fmt.Errorf("failed to open channel: %w", err)
+	}
+	defer ch.Close()
+
+	if err := ch.Confirm(false); err != nil {
+		return fmt.Errorf("failed to enable publisher confirm: %w", err)
+	}
+
+	err = ch.ExchangeDeclare(
+		constants.ENUM_EXCHANGE_WORKER_EVENTS,
+		amqp.ExchangeTopic,
+		true,  // durable
+		false, // auto-delete
+		false, // internal
+		false, // no-wait
+		nil,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to declare exchange: %w", err)
+	}
+
+	obs.logger.Info("outbox relay started")
+	connected()
+
+	ticker := time.NewTicker(constants.ENUM_OUTBOX_RELAY_POLL_INTERVAL * time.Millisecond)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+			// kuras antrean selama batch masih penuh
+			for !ch.IsClosed() {
+				relayed, err := obs.relayBatch(ctx, confirmChannel{ch})
+				if err != nil {
+					obs.logger.Error("failed to relay outbox events", zap.Error(err))
+					break
+				}
+				if relayed < constants.ENUM_OUTBOX_RELAY_BATCH_SIZE {
+					break
+				}
+			}
+
+			if ch.IsClosed() {
+				return fmt.Errorf("channel closed")
+			}
+		}
+	}
+}
+
+func (obs *outboxService) relayBatch(ctx context.Context, ch outboxPublisher) (int, error) {
+	var relayed int
+
+	start := time.Now()
+
+	err := obs.txManager.WithTransaction(ctx, func(tx *gorm.DB) error {
+		events, err := obs.outboxRepo.GetPendingOutboxEventsForUpdate(ctx, tx, constants.ENUM_OUTBOX_RELAY_BATCH_SIZE)
+		if err != nil {
+			return err
+		}
+		relayed = len(events)
+
+		for _, event := range events {
+			// channel putus di tengah batch: sisa event tetap pending tanpa menambah attempts
+			if ch.IsClosed() {
+				break
+			}
+
+			if err := ch.PublishEvent(ctx, event); err != nil {
+				event.Attempts++
+				event.LastError = err.Error()
+				event.NextAttemptAt = time.Now().Add(outboxBackoff(event.Attempts))
+				if event.Attempts >= constants.ENUM_OUTBOX_MAX_ATTEMPTS {
+					event.Status = entity.OUTBOX_FAILED
+				}
+
+				obs.logger.Warn("failed to publish outbox event",
+					zap.String("event_id", event.ID.String()),
+					zap.String("event_type", event.EventType),
+					zap.Int("attempts", event.Attempts),
+					zap.Error(err),
+				)
+
+				if err := obs.outboxRepo.MarkOutboxEventFailed(ctx, tx, event); err != nil {
+					return err
+				}
+				continue
+			}
+
+			if err := obs.outboxRepo.MarkOutboxEventSent(ctx, tx, event.ID); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+	metrics.DBWriteDuration.WithLabelValues(constants.ENUM_DB_OPERATION_RELAY_OUTBOX, dbStatus(err)).Observe(time.Since(start).Seconds())
+
+	return relayed, err
+}
+
+func (c confirmChannel) PublishEvent(ctx context.Context, event entity.OutboxEvent) error {
+	return publishOutboxEvent(ctx, c.Channel, event)
+}
+
+// publishOutboxEvent membuat span producer sebagai anak trace context yang disimpan saat event
+// dibuat, lalu meneruskannya sebagai header traceparent.
+func publishOutboxEvent(ctx context.Context, ch *amqp.Channel, event entity.OutboxEvent) (err error) {
+	headers := amqp.Table{}
+	for k, v := range event.Headers {
+		headers[k] = v
+	}
+
+	ctx, span := tracing.Tracer().Start(tracing.ExtractAMQP(ctx, headers), constants.ENUM_EXCHANGE_WORKER_EVENTS+" publish",
+		trace.WithSpanKind(trace.SpanKindProducer),
+		trace.WithAttributes(
+			semconv.MessagingSystemRabbitMQ,
+			semconv.MessagingOperationTypeSend,
+			semconv.MessagingDestinationName(constants.ENUM_EXCHANGE_WORKER_EVENTS),
+			semconv.MessagingMessageID(event.ID.String()),
+		),
+	)
+	defer func() { tracing.End(span, err) }()
+
+	tracing.InjectAMQP(ctx, headers)
+
+	return helper.PublishWithConfirm(ctx, ch,
+		constants.ENUM_EXCHANGE_WORKER_EVENTS,
+		event.EventType, // routing key
+		amqp.Publishing{
+			ContentType:  "application/json",
+			DeliveryMode: amqp.Persistent,
+			MessageId:    event.ID.String(),
+			Type:         event.EventType,
+			Timestamp:    event.CreatedAt,
+			Headers:      headers,
+			Body:         event.Payload,
+		},
+	)
+}
+
+// backoff eksponensial 2^attempts detik, dibatasi ENUM_OUTBOX_MAX_BACKOFF
+func outboxBackoff(attempts int) time.Duration {
+	maxBackoff := constants.ENUM_OUTBOX_MAX_BACKOFF * time.Millisecond
+	if attempts > 16 {
+		return maxBackoff
+	}
+
+	backoff := time.Duration(1<<attempts) * time.Second
+	if backoff > maxBackoff {
+		return maxBackoff
+	}
+
+	return backoff
+}
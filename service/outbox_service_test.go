@@ -0,0 +1,107 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/Amierza/worker-service/constants"
+	"github.com/Amierza/worker-service/entity"
+	"github.com/Amierza/worker-service/internal/testdb"
+	"github.com/Amierza/worker-service/repository"
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+// fakeOutboxPublisher gagal untuk event di failFor, event lain dianggap ter-confirm broker.
+type fakeOutboxPublisher struct {
+	failFor   map[uuid.UUID]bool
+	published []uuid.UUID
+}
+
+func (p *fakeOutboxPublisher) IsClosed() bool {
+	return false
+}
+
+func (p *fakeOutboxPublisher) PublishEvent(_ context.Context, event entity.OutboxEvent) error {
+	if p.failFor[event.ID] {
+		return errors.New("publish nacked")
+	}
+	p.published = append(p.published, event.ID)
+	return nil
+}
+
+func TestRelayBatchMarksSentAndFailedEvents(t *testing.T) {
+	db := testdb.New(t, &entity.OutboxEvent{})
+
+	var (
+		past        = time.Now().Add(-time.Minute)
+		sent        = entity.OutboxEvent{ID: uuid.New(), Attempts: 2, LastError: "timeout"}
+		retried     = entity.OutboxEvent{ID: uuid.New(), Attempts: 2}
+		exhausted   = entity.OutboxEvent{ID: uuid.New(), Attempts: constants.ENUM_OUTBOX_MAX_ATTEMPTS - 1}
+		notDueYet   = entity.OutboxEvent{ID: uuid.New(), NextAttemptAt: time.Now().Add(time.Hour)}
+		alreadySent = entity.OutboxEvent{ID: uuid.New(), Status: entity.OUTBOX_SENT}
+	)
+	for _, event := range []*entity.OutboxEvent{&sent, &retried, &exhausted, &notDueYet, &alreadySent} {
+		event.EventType = constants.ENUM_EVENT_SUMMARY_GENERATED
+		event.Payload = json.RawMessage(`{}`)
+		if event.Status == "" {
+			event.Status = entity.OUTBOX_PENDING
+		}
+		if event.NextAttemptAt.IsZero() {
+			event.NextAttemptAt = past
+		}
+		if err := db.Create(event).Error; err != nil {
+			t.Fatalf("failed to seed outbox event: %v", err)
+		}
+	}
+
+	obs := NewOutboxService(repository.NewOutboxRepository(db), repository.NewTransactionManager(db), zap.NewNop(), nil)
+	publisher := &fakeOutboxPublisher{failFor: map[uuid.UUID]bool{retried.ID: true, exhausted.ID: true}}
+
+	before := time.Now()
+	relayed, err := obs.relayBatch(context.Background(), publisher)
+	if err != nil {
+		t.Fatalf("relayBatch() error = %v", err)
+	}
+	if relayed != 3 {
+		t.Fatalf("relayed = %d, want 3 (only due pending events)", relayed)
+	}
+	if len(publisher.published) != 1 || publisher.published[0] != sent.ID {
+		t.Fatalf("published = %v, want only %s", publisher.published, sent.ID)
+	}
+
+	load := func(id uuid.UUID) entity.OutboxEvent {
+		t.Helper()
+		var event entity.OutboxEvent
+		if err := db.First(&event, "id = ?", id).Error; err != nil {
+			t.Fatalf("failed to load outbox event %s: %v", id, err)
+		}
+		return event
+	}
+
+	got := load(sent.ID)
+	if got.Status != entity.OUTBOX_SENT || got.SentAt == nil || got.LastError != "" {
+		t.Fatalf("sent event = status %q, sent_at %v, last_error %q", got.Status, got.SentAt, got.LastError)
+	}
+
+	got = load(retried.ID)
+	if got.Status != entity.OUTBOX_PENDING || got.Attempts != 3 || got.LastError == "" {
+		t.Fatalf("retried event = status %q, attempts %d, last_error %q", got.Status, got.Attempts, got.LastError)
+	}
+	if wantNext := before.Add(outboxBackoff(3)); got.NextAttemptAt.Before(wantNext) {
+		t.Fatalf("retried next_attempt_at = %v, want >= %v", got.NextAttemptAt, wantNext)
+	}
+
+	got = load(exhausted.ID)
+	if got.Status != entity.OUTBOX_FAILED || got.Attempts != constants.ENUM_OUTBOX_MAX_ATTEMPTS {
+		t.Fatalf("exhausted event = status %q, attempts %d, want %q after %d attempts",
+			got.Status, got.Attempts, entity.OUTBOX_FAILED, constants.ENUM_OUTBOX_MAX_ATTEMPTS)
+	}
+
+	if got = load(notDueYet.ID); got.Status != entity.OUTBOX_PENDING || got.Attempts != 0 {
+		t.Fatalf("not-due event = status %q, attempts %d, want untouched", got.Status, got.Attempts)
+	}
+}
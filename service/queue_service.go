@@ -0,0 +1,155 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/Amierza/worker-service/constants"
+	"github.com/Amierza/worker-service/dto"
+	"github.com/Amierza/worker-service/helper"
+	"github.com/google/uuid"
+	amqp "github.com/rabbitmq/amqp091-go"
+	"go.uber.org/zap"
+)
+
+type (
+	// IQueueService adalah operasi queue yang hanya butuh koneksi RabbitMQ, dipakai oleh
+	// perintah CLI enqueue & replay-dlq.
+	IQueueService interface {
+		EnqueueSummaryTask(ctx context.Context, body []byte) (uuid.UUID, error)
+		ReplayDeadLetters(ctx context.Context, limit int) (int, error)
+	}
+
+	queueService struct {
+		logger   *zap.Logger
+		rabbitmq *amqp.Connection
+	}
+)
+
+func NewQueueService(logger *zap.Logger, rabbitmq *amqp.Connection) *queueService {
+	return &queueService{
+		logger:   logger,
+		rabbitmq: rabbitmq,
+	}
+}
+
+// EnqueueSummaryTask memvalidasi body sebagai TaskSummary lalu mengirimnya sebagai task baru.
+func (qs *queueService) EnqueueSummaryTask(ctx context.Context, body []byte) (uuid.UUID, error) {
+	var task dto.TaskSummary
+	if err := json.Unmarshal(body, &task); err != nil {
+		return uuid.Nil, fmt.Errorf("%w: %v", dto.ErrInvalidTaskPayload, err)
+	}
+	if task.SessionID == uuid.Nil {
+		return uuid.Nil, fmt.Errorf("%w: session_id is required", dto.ErrInvalidTaskPayload)
+	}
+
+	ch, err := qs.openConfirmChannel()
+	if err != nil {
+		return uuid.Nil, err
+	}
+	defer ch.Close()
+
+	taskID := uuid.New()
+	if err := publishSummaryTask(ctx, ch, taskID, body); err != nil {
+		return uuid.Nil, fmt.Errorf("%w: %w", dto.ErrPublishTask, err)
+	}
+
+	qs.logger.Info("summary task enqueued",
+		zap.String("task_id", taskID.String()),
+		zap.String("session_id", task.SessionID.String()),
+	)
+
+	return taskID, nil
+}
+
+// ReplayDeadLetters memindahkan pesan dari DLQ kembali ke queue utama dengan retry count
+// di-reset. Pesan di-ack dari DLQ hanya setelah publish dikonfirmasi broker, limit 0 berarti
+// semua pesan yang ada saat ini.
+func (qs *queueService) ReplayDeadLetters(ctx context.Context, limit int) (int, error) {
+	ch, err := qs.openConfirmChannel()
+	if err != nil {
+		return 0, err
+	}
+	defer ch.Close()
+
+	// jumlah awal dipakai sebagai batas agar pesan yang gagal lagi selama replay tidak diulang terus
+	queue, err := ch.QueueDeclarePassive(constants.ENUM_QUEUE_SUMMARY_TASK_DLQ, true, false, false, false, nil)
+	if err != nil {
+		return 0, fmt.Errorf("failed to inspect dead letter queue: %w", err)
+	}
+
+	total := queue.Messages
+	if limit > 0 && limit < total {
+		total = limit
+	}
+
+	replayed := 0
+	for replayed < total {
+		if err := ctx.Err(); err != nil {
+			return replayed, err
+		}
+
+		msg, ok, err := ch.Get(constants.ENUM_QUEUE_SUMMARY_TASK_DLQ, false)
+		if err != nil {
+			return replayed, fmt.Errorf("failed to get dead letter: %w", err)
+		}
+		if !ok {
+			break
+		}
+
+		headers := amqp.Table{}
+		for k, v := range msg.Headers {
+			headers[k] = v
+		}
+		delete(headers, constants.ENUM_HEADER_RETRY_COUNT)
+		delete(headers, constants.ENUM_HEADER_LAST_ERROR)
+
+		err = helper.PublishWithConfirm(ctx, ch, "", constants.ENUM_QUEUE_SUMMARY_TASK, amqp.Publishing{
+			ContentType:   msg.ContentType,
+			DeliveryMode:  amqp.Persistent,
+			MessageId:     msg.MessageId,
+			CorrelationId: msg.CorrelationId,
+			Timestamp:     msg.Timestamp,
+			Headers:       headers,
+			Body:          msg.Body,
+		})
+		if err != nil {
+			if nackErr := msg.Nack(false, true); nackErr != nil {
+				qs.logger.Error("failed to return dead letter to queue", zap.Error(nackErr))
+			}
+			return replayed, fmt.Errorf("failed to republish dead letter: %w", err)
+		}
+
+		if err := msg.Ack(false); err != nil {
+			return replayed, fmt.Errorf("failed to ack dead letter: %w", err)
+		}
+
+		replayed++
+		qs.logger.Info("dead letter replayed",
+			zap.String("message_id", msg.MessageId),
+			zap.Any("task_id", msg.Headers[constants.ENUM_HEADER_TASK_ID]),
+		)
+	}
+
+	return replayed, nil
+}
+
+func (qs *queueService) openConfirmChannel() (*amqp.Channel, error) {
+	ch, err := qs.rabbitmq.Channel()
+	if err != nil {
+		return nil, fmt.Errorf("failed to open channel: %w", err)
+	}
+
+	if err := ch.Confirm(false); err != nil {
+		ch.Close()
+		return nil, fmt.Errorf("failed to enable publisher confirm: %w", err)
+	}
+
+	if err := declareSummaryQueues(ch); err != nil {
+		ch.Close()
+		return nil, err
+	}
+
+	return ch, nil
+}
@@ -0,0 +1,123 @@
+package service
+
+import (
+	"context"
+
+	"github.com/Amierza/worker-service/dto"
+	"github.com/Amierza/worker-service/entity"
+	"github.com/Amierza/worker-service/repository"
+	"github.com/google/uuid"
+)
+
+type (
+	ISummaryService interface {
+		GetSessionSummary(ctx context.Context, sessionID string) (dto.SessionSummaryResponse, error)
+		GetThesisSummaries(ctx context.Context, thesisID string) (dto.ThesisSummariesResponse, error)
+	}
+
+	summaryService struct {
+		summaryRepo repository.ISummaryRepository
+	}
+)
+
+func NewSummaryService(summaryRepo repository.ISummaryRepository) *summaryService {
+	return &summaryService{
+		summaryRepo: summaryRepo,
+	}
+}
+
+// GetSessionSummary & GetThesisSummaries tidak mengecek akses; pengecekan dilakukan
+// middleware.SessionAccess / middleware.ThesisAccess pada route.
+func (ss *summaryService) GetSessionSummary(ctx context.Context, sessionID string) (dto.SessionSummaryResponse, error) {
+	sid, err := uuid.Parse(sessionID)
+	if err != nil {
+		return dto.SessionSummaryResponse{}, dto.ErrInvalidSessionID
+	}
+
+	session, err := ss.summaryRepo.GetSessionByID(ctx, nil, sid)
+	if err != nil {
+		return dto.SessionSummaryResponse{}, err
+	}
+
+	summaries, err := ss.summaryRepo.GetSummariesBySessionIDs(ctx, nil, []uuid.UUID{sid})
+	if err != nil {
+		return dto.SessionSummaryResponse{}, err
+	}
+
+	if len(summaries) == 0 {
+		return dto.SessionSummaryResponse{}, dto.ErrSummaryNotFound
+	}
+
+	return toSessionSummaryResponse(session, summaries), nil
+}
+
+// GetThesisSummaries mengembalikan ringkasan seluruh sesi bimbingan pada thesis; sesi tanpa ringkasan dilewati.
+func (ss *summaryService) GetThesisSummaries(ctx context.Context, thesisID string) (dto.ThesisSummariesResponse, error) {
+	tid, err := uuid.Parse(thesisID)
+	if err != nil {
+		return dto.ThesisSummariesResponse{}, dto.ErrInvalidThesisID
+	}
+
+	thesis, err := ss.summaryRepo.GetThesisByID(ctx, nil, tid)
+	if err != nil {
+		return dto.ThesisSummariesResponse{}, err
+	}
+
+	sessions, err := ss.summaryRepo.GetSessionsByThesisID(ctx, nil, tid)
+	if err != nil {
+		return dto.ThesisSummariesResponse{}, err
+	}
+
+	sessionIDs := make([]uuid.UUID, 0, len(sessions))
+	for _, session := range sessions {
+		sessionIDs = append(sessionIDs, session.ID)
+	}
+
+	summaries, err := ss.summaryRepo.GetSummariesBySessionIDs(ctx, nil, sessionIDs)
+	if err != nil {
+		return dto.ThesisSummariesResponse{}, err
+	}
+
+	bySession := make(map[uuid.UUID][]entity.Summary, len(sessions))
+	for _, summary := range summaries {
+		bySession[summary.SessionID] = append(bySession[summary.SessionID], summary)
+	}
+
+	res := dto.ThesisSummariesResponse{
+		ThesisID: thesis.ID,
+		Title:    thesis.Title,
+		Sessions: make([]dto.SessionSummaryResponse, 0, len(bySession)),
+	}
+	for _, session := range sessions {
+		if versions, ok := bySession[session.ID]; ok {
+			res.Sessions = append(res.Sessions, toSessionSummaryResponse(session, versions))
+		}
+	}
+
+	return res, nil
+}
+
+// toSessionSummaryResponse mengharapkan summaries terurut dari versi terbaru.
+func toSessionSummaryResponse(session entity.Session, summaries []entity.Summary) dto.SessionSummaryResponse {
+	history := make([]dto.SummaryResponse, 0, len(summaries))
+	for _, summary := range summaries {
+		history = append(history, dto.SummaryResponse{
+			ID:        summary.ID,
+			Version:   summary.Version,
+			Content:   summary.Content,
+			CreatedAt: summary.CreatedAt,
+		})
+	}
+
+	return dto.SessionSummaryResponse{
+		SessionID:     session.ID,
+		ThesisID:      session.ThesisID,
+		Status:        session.Status,
+		StartTime:     session.StartTime,
+		EndTime:       session.EndTime,
+		LatestVersion: history[0].Version,
+		TotalVersions: len(history),
+		Summary:       history[0],
+		History:       history,
+	}
+}
@@ -0,0 +1,242 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/Amierza/worker-service/constants"
+	"github.com/Amierza/worker-service/dto"
+	"github.com/Amierza/worker-service/entity"
+	"github.com/Amierza/worker-service/helper"
+	"github.com/Amierza/worker-service/logger"
+	"github.com/Amierza/worker-service/repository"
+	"github.com/Amierza/worker-service/tracing"
+	"github.com/google/uuid"
+	amqp "github.com/rabbitmq/amqp091-go"
+	"go.uber.org/zap"
+)
+
+type (
+	ITaskService interface {
+		GetAllTasks(ctx context.Context, req dto.TaskPaginationRequest) (dto.TaskPaginationResponse, error)
+		GetTaskDetail(ctx context.Context, id string) (dto.TaskDetailResponse, error)
+		RetryTask(ctx context.Context, id string) (dto.TaskResponse, error)
+		ResummarizeSession(ctx context.Context, sessionID string) (dto.TaskResponse, error)
+	}
+
+	taskService struct {
+		taskRepo     repository.ITaskRepository
+		consumerRepo repository.IConsumerRepository
+		logger       *zap.Logger
+		publisher    summaryTaskPublisher
+	}
+
+	// summaryTaskPublisher dipenuhi amqpTaskPublisher; dipisah supaya retry & resummarize bisa diuji tanpa broker.
+	summaryTaskPublisher interface {
+		PublishSummaryTask(ctx context.Context, taskID uuid.UUID, body []byte) error
+	}
+
+	// amqpTaskPublisher membuka channel confirm baru untuk setiap publish.
+	amqpTaskPublisher struct {
+		rabbitmq *amqp.Connection
+	}
+)
+
+func NewTaskService(taskRepo repository.ITaskRepository, consumerRepo repository.IConsumerRepository, logger *zap.Logger, rabbitmq *amqp.Connection) *taskService {
+	return &taskService{
+		taskRepo:     taskRepo,
+		consumerRepo: consumerRepo,
+		logger:       logger,
+		publisher:    amqpTaskPublisher{rabbitmq: rabbitmq},
+	}
+}
+
+func (ts *taskService) GetAllTasks(ctx context.Context, req dto.TaskPaginationRequest) (dto.TaskPaginationResponse, error) {
+	if req.Status != "" && !entity.IsValidTaskStatus(entity.TaskStatus(req.Status)) {
+		return dto.TaskPaginationResponse{}, dto.ErrInvalidTaskStatus
+	}
+
+	if req.SessionID != "" {
+		if _, err := uuid.Parse(req.SessionID); err != nil {
+			return dto.TaskPaginationResponse{}, dto.ErrInvalidSessionID
+		}
+	}
+
+	datas, err := ts.taskRepo.GetAllTaskLedgersWithPagination(ctx, nil, req)
+	if err != nil {
+		return dto.TaskPaginationResponse{}, err
+	}
+
+	tasks := make([]dto.TaskResponse, 0, len(datas.Tasks))
+	for _, ledger := range datas.Tasks {
+		tasks = append(tasks, toTaskResponse(ledger))
+	}
+
+	return dto.TaskPaginationResponse{
+		Data:               tasks,
+		PaginationResponse: datas.PaginationResponse,
+	}, nil
+}
+
+func (ts *taskService) GetTaskDetail(ctx context.Context, id string) (dto.TaskDetailResponse, error) {
+	taskID, err := uuid.Parse(id)
+	if err != nil {
+		return dto.TaskDetailResponse{}, dto.ErrInvalidTaskID
+	}
+
+	ledger, err := ts.taskRepo.GetTaskLedgerByID(ctx, nil, taskID)
+	if err != nil {
+		return dto.TaskDetailResponse{}, err
+	}
+
+	res := dto.TaskDetailResponse{TaskResponse: toTaskResponse(ledger)}
+	if len(ledger.Payload) > 0 {
+		var payload dto.TaskSummary
+		if err := json.Unmarshal(ledger.Payload, &payload); err == nil {
+			res.Payload = &payload
+		}
+	}
+
+	return res, nil
+}
+
+// RetryTask mengirim ulang payload task yang tersimpan ke queue dengan id task yang sama.
+func (ts *taskService) RetryTask(ctx context.Context, id string) (dto.TaskResponse, error) {
+	taskID, err := uuid.Parse(id)
+	if err != nil {
+		return dto.TaskResponse{}, dto.ErrInvalidTaskID
+	}
+
+	ledger, err := ts.taskRepo.GetTaskLedgerByID(ctx, nil, taskID)
+	if err != nil {
+		return dto.TaskResponse{}, err
+	}
+
+	if ledger.Status == entity.TASK_QUEUED || ledger.Status == entity.TASK_PROCESSING {
+		return dto.TaskResponse{}, dto.ErrTaskNotRetryable
+	}
+
+	if len(ledger.Payload) == 0 {
+		return dto.TaskResponse{}, dto.ErrTaskHasNoPayload
+	}
+
+	// status di-set sebelum publish agar tidak menimpa status processing dari consumer
+	previous := ledger.Status
+	if err := ts.taskRepo.UpdateTaskLedgerStatus(ctx, nil, taskID, entity.TASK_QUEUED); err != nil {
+		return dto.TaskResponse{}, err
+	}
+
+	if err := ts.publisher.PublishSummaryTask(ctx, taskID, ledger.Payload); err != nil {
+		if err := ts.taskRepo.UpdateTaskLedgerStatus(ctx, nil, taskID, previous); err != nil {
+			ts.logger.Error("failed to restore task status", zap.String("task_id", taskID.String()), zap.Error(err))
+		}
+		return dto.TaskResponse{}, fmt.Errorf("%w: %w", dto.ErrPublishTask, err)
+	}
+
+	ts.logger.Info("task requeued manually", zap.String("task_id", taskID.String()))
+
+	ledger.Status = entity.TASK_QUEUED
+	return toTaskResponse(ledger), nil
+}
+
+// ResummarizeSession menyusun ulang task dari database lalu mengirimnya sebagai task baru.
+func (ts *taskService) ResummarizeSession(ctx context.Context, sessionID string) (dto.TaskResponse, error) {
+	id, err := uuid.Parse(sessionID)
+	if err != nil {
+		return dto.TaskResponse{}, dto.ErrInvalidSessionID
+	}
+
+	task, err := ts.consumerRepo.GetTaskSummaryBySessionID(ctx, nil, id)
+	if err != nil {
+		return dto.TaskResponse{}, err
+	}
+
+	body, err := json.Marshal(task)
+	if err != nil {
+		return dto.TaskResponse{}, fmt.Errorf("failed to encode task: %w", err)
+	}
+
+	ledger := entity.TaskLedger{
+		ID:         uuid.New(),
+		TaskType:   constants.ENUM_TASK_TYPE_SUMMARY,
+		Status:     entity.TASK_QUEUED,
+		Payload:    body,
+		ReceivedAt: time.Now(),
+		SessionID:  id,
+	}
+	if err := ts.taskRepo.CreateTaskLedger(ctx, nil, ledger); err != nil {
+		return dto.TaskResponse{}, err
+	}
+
+	if err := ts.publisher.PublishSummaryTask(ctx, ledger.ID, body); err != nil {
+		// tandai dead_lettered agar bisa di-retry manual
+		if err := ts.taskRepo.UpdateTaskLedgerResult(ctx, nil, ledger.ID, entity.TASK_DEAD_LETTERED, err.Error(), time.Now(), 0); err != nil {
+			ts.logger.Error("failed to record publish failure", zap.String("task_id", ledger.ID.String()), zap.Error(err))
+		}
+		return dto.TaskResponse{}, fmt.Errorf("%w: %w", dto.ErrPublishTask, err)
+	}
+
+	ts.logger.Info("session resummarize requested",
+		zap.String("task_id", ledger.ID.String()),
+		zap.String("session_id", id.String()),
+	)
+
+	return toTaskResponse(ledger), nil
+}
+
+func (p amqpTaskPublisher) PublishSummaryTask(ctx context.Context, taskID uuid.UUID, body []byte) error {
+	ch, err := p.rabbitmq.Channel()
+	if err != nil {
+		return err
+	}
+	defer ch.Close()
+
+	if err := ch.Confirm(false); err != nil {
+		return err
+	}
+
+	if err := declareSummaryQueues(ch); err != nil {
+		return err
+	}
+
+	return publishSummaryTask(ctx, ch, taskID, body)
+}
+
+// publishSummaryTask mengirim task ke queue summary_task; channel harus dalam confirm mode.
+// Correlation id diambil dari ctx (mis. request id HTTP), jika kosong memakai id task.
+func publishSummaryTask(ctx context.Context, ch *amqp.Channel, taskID uuid.UUID, body []byte) error {
+	correlationID := logger.CorrelationIDFromContext(ctx)
+	if correlationID == "" {
+		correlationID = taskID.String()
+	}
+
+	headers := amqp.Table{constants.ENUM_HEADER_TASK_ID: taskID.String()}
+	tracing.InjectAMQP(ctx, headers)
+
+	return helper.PublishWithConfirm(ctx, ch, "", constants.ENUM_QUEUE_SUMMARY_TASK, amqp.Publishing{
+		ContentType:   "application/json",
+		DeliveryMode:  amqp.Persistent,
+		MessageId:     taskID.String(),
+		CorrelationId: correlationID,
+		Timestamp:     time.Now(),
+		Headers:       headers,
+		Body:          body,
+	})
+}
+
+func toTaskResponse(ledger entity.TaskLedger) dto.TaskResponse {
+	return dto.TaskResponse{
+		ID:         ledger.ID,
+		TaskType:   ledger.TaskType,
+		Status:     ledger.Status,
+		Attempts:   ledger.Attempts,
+		LastError:  ledger.LastError,
+		SessionID:  ledger.SessionID,
+		ReceivedAt: ledger.ReceivedAt,
+		StartedAt:  ledger.StartedAt,
+		FinishedAt: ledger.FinishedAt,
+		DurationMs: ledger.DurationMs,
+	}
+}
@@ -0,0 +1,182 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/Amierza/worker-service/constants"
+	"github.com/Amierza/worker-service/dto"
+	"github.com/Amierza/worker-service/entity"
+	"github.com/Amierza/worker-service/internal/testdb"
+	"github.com/Amierza/worker-service/repository"
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+// fakeTaskPublisher mencatat task yang dikirim, atau gagal dengan err.
+type fakeTaskPublisher struct {
+	err       error
+	published []uuid.UUID
+	bodies    [][]byte
+}
+
+func (p *fakeTaskPublisher) PublishSummaryTask(_ context.Context, taskID uuid.UUID, body []byte) error {
+	if p.err != nil {
+		return p.err
+	}
+	p.published = append(p.published, taskID)
+	p.bodies = append(p.bodies, body)
+	return nil
+}
+
+func newTestTaskService(t *testing.T, publisher *fakeTaskPublisher) (*taskService, *gorm.DB) {
+	t.Helper()
+
+	db := testdb.New(t, &entity.TaskLedger{})
+	ts := NewTaskService(repository.NewTaskRepository(db), taskStubConsumerRepo{}, zap.NewNop(), nil)
+	ts.publisher = publisher
+
+	return ts, db
+}
+
+func loadTaskLedger(t *testing.T, db *gorm.DB, id uuid.UUID) entity.TaskLedger {
+	t.Helper()
+
+	var ledger entity.TaskLedger
+	if err := db.First(&ledger, "id = ?", id).Error; err != nil {
+		t.Fatalf("failed to load task ledger %s: %v", id, err)
+	}
+
+	return ledger
+}
+
+func TestRetryTask(t *testing.T) {
+	payload := json.RawMessage(`{"session_id":"3f0c2a8e-7a4b-4d8c-9a51-0a7b1f2d3c4e"}`)
+
+	tests := []struct {
+		name        string
+		status      entity.TaskStatus
+		payload     json.RawMessage
+		publishErr  error
+		wantErr     error
+		wantStatus  entity.TaskStatus
+		wantPublish bool
+	}{
+		{name: "dead lettered is requeued", status: entity.TASK_DEAD_LETTERED, payload: payload, wantStatus: entity.TASK_QUEUED, wantPublish: true},
+		{name: "succeeded is requeued", status: entity.TASK_SUCCEEDED, payload: payload, wantStatus: entity.TASK_QUEUED, wantPublish: true},
+		// publish gagal: status dikembalikan supaya task tidak tampak queued selamanya
+		{name: "publish failure restores status", status: entity.TASK_DEAD_LETTERED, payload: payload, publishErr: errInjected, wantErr: dto.ErrPublishTask, wantStatus: entity.TASK_DEAD_LETTERED},
+		{name: "queued is not retryable", status: entity.TASK_QUEUED, payload: payload, wantErr: dto.ErrTaskNotRetryable, wantStatus: entity.TASK_QUEUED},
+		{name: "processing is not retryable", status: entity.TASK_PROCESSING, payload: payload, wantErr: dto.ErrTaskNotRetryable, wantStatus: entity.TASK_PROCESSING},
+		{name: "missing payload", status: entity.TASK_DEAD_LETTERED, wantErr: dto.ErrTaskHasNoPayload, wantStatus: entity.TASK_DEAD_LETTERED},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			publisher := &fakeTaskPublisher{err: tt.publishErr}
+			ts, db := newTestTaskService(t, publisher)
+
+			ledger := entity.TaskLedger{
+				ID:         uuid.New(),
+				TaskType:   constants.ENUM_TASK_TYPE_SUMMARY,
+				Status:     tt.status,
+				Payload:    tt.payload,
+				ReceivedAt: time.Now(),
+				SessionID:  uuid.New(),
+			}
+			if err := db.Create(&ledger).Error; err != nil {
+				t.Fatalf("failed to seed task ledger: %v", err)
+			}
+
+			res, err := ts.RetryTask(context.Background(), ledger.ID.String())
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("RetryTask() error = %v, want %v", err, tt.wantErr)
+			}
+			if err == nil && (res.ID != ledger.ID || res.Status != entity.TASK_QUEUED) {
+				t.Errorf("response = %s %q, want %s %q", res.ID, res.Status, ledger.ID, entity.TASK_QUEUED)
+			}
+
+			if got := loadTaskLedger(t, db, ledger.ID).Status; got != tt.wantStatus {
+				t.Errorf("stored status = %q, want %q", got, tt.wantStatus)
+			}
+
+			if !tt.wantPublish {
+				if len(publisher.published) != 0 {
+					t.Errorf("published %d tasks, want none", len(publisher.published))
+				}
+				return
+			}
+			// id task tetap sama supaya consumer memperbarui ledger yang sama
+			if len(publisher.published) != 1 || publisher.published[0] != ledger.ID || string(publisher.bodies[0]) != string(tt.payload) {
+				t.Errorf("published = %v, want task %s with stored payload", publisher.published, ledger.ID)
+			}
+		})
+	}
+
+	t.Run("invalid id", func(t *testing.T) {
+		ts, _ := newTestTaskService(t, &fakeTaskPublisher{})
+		if _, err := ts.RetryTask(context.Background(), "not-a-uuid"); !errors.Is(err, dto.ErrInvalidTaskID) {
+			t.Errorf("RetryTask() error = %v, want ErrInvalidTaskID", err)
+		}
+	})
+}
+
+func TestResummarizeSession(t *testing.T) {
+	tests := []struct {
+		name       string
+		publishErr error
+		wantErr    error
+		wantStatus entity.TaskStatus
+	}{
+		{name: "published", wantStatus: entity.TASK_QUEUED},
+		// publish gagal: ledger ditandai dead_lettered agar bisa di-retry manual
+		{name: "publish failure", publishErr: errInjected, wantErr: dto.ErrPublishTask, wantStatus: entity.TASK_DEAD_LETTERED},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			publisher := &fakeTaskPublisher{err: tt.publishErr}
+			ts, db := newTestTaskService(t, publisher)
+			sessionID := uuid.New()
+
+			res, err := ts.ResummarizeSession(context.Background(), sessionID.String())
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("ResummarizeSession() error = %v, want %v", err, tt.wantErr)
+			}
+
+			var ledgers []entity.TaskLedger
+			if err := db.Find(&ledgers).Error; err != nil {
+				t.Fatalf("failed to load task ledgers: %v", err)
+			}
+			if len(ledgers) != 1 {
+				t.Fatalf("got %d task ledgers, want 1", len(ledgers))
+			}
+			ledger := ledgers[0]
+			if ledger.SessionID != sessionID || ledger.Status != tt.wantStatus {
+				t.Errorf("ledger = session %s status %q, want %s %q", ledger.SessionID, ledger.Status, sessionID, tt.wantStatus)
+			}
+
+			var payload dto.TaskSummary
+			if err := json.Unmarshal(ledger.Payload, &payload); err != nil || payload.SessionID != sessionID {
+				t.Errorf("stored payload session = %s (%v), want %s", payload.SessionID, err, sessionID)
+			}
+
+			if tt.wantErr != nil {
+				if ledger.LastError == "" {
+					t.Error("last_error is empty, want publish error")
+				}
+				return
+			}
+			if res.ID != ledger.ID || res.Status != entity.TASK_QUEUED {
+				t.Errorf("response = %s %q, want %s %q", res.ID, res.Status, ledger.ID, entity.TASK_QUEUED)
+			}
+			if len(publisher.published) != 1 || publisher.published[0] != ledger.ID {
+				t.Errorf("published = %v, want new task %s", publisher.published, ledger.ID)
+			}
+		})
+	}
+}
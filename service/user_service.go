@@ -0,0 +1,42 @@
+package service
+
+import (
+	"context"
+
+	"github.com/Amierza/worker-service/dto"
+	"github.com/Amierza/worker-service/i18n"
+	"github.com/Amierza/worker-service/repository"
+	"github.com/google/uuid"
+)
+
+type (
+	IUserService interface {
+		GetUserLocale(ctx context.Context, userID string) (string, error)
+	}
+
+	userService struct {
+		userRepo repository.IUserRepository
+	}
+)
+
+func NewUserService(userRepo repository.IUserRepository) *userService {
+	return &userService{
+		userRepo: userRepo,
+	}
+}
+
+// GetUserLocale mengembalikan locale profil user yang sudah dinormalisasi, string kosong jika
+// locale di profil tidak didukung.
+func (us *userService) GetUserLocale(ctx context.Context, userID string) (string, error) {
+	id, err := uuid.Parse(userID)
+	if err != nil {
+		return "", dto.ErrInvalidUserID
+	}
+
+	locale, err := us.userRepo.GetUserLocaleByID(ctx, nil, id)
+	if err != nil {
+		return "", err
+	}
+
+	return i18n.Normalize(locale), nil
+}
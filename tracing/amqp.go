@@ -0,0 +1,63 @@
+package tracing
+
+import (
+	"context"
+
+	amqp "github.com/rabbitmq/amqp091-go"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/propagation"
+)
+
+// amqpHeadersCarrier mengadaptasi header AMQP sebagai carrier propagator (traceparent, tracestate, baggage).
+type amqpHeadersCarrier amqp.Table
+
+func (c amqpHeadersCarrier) Get(key string) string {
+	switch v := c[key].(type) {
+	case string:
+		return v
+	case []byte:
+		return string(v)
+	default:
+		return ""
+	}
+}
+
+func (c amqpHeadersCarrier) Set(key, value string) {
+	c[key] = value
+}
+
+func (c amqpHeadersCarrier) Keys() []string {
+	keys := make([]string, 0, len(c))
+	for k := range c {
+		keys = append(keys, k)
+	}
+
+	return keys
+}
+
+// ExtractAMQP mengambil trace context W3C dari header delivery sehingga span consumer menjadi
+// anak dari span publisher.
+func ExtractAMQP(ctx context.Context, headers amqp.Table) context.Context {
+	if headers == nil {
+		return ctx
+	}
+
+	return otel.GetTextMapPropagator().Extract(ctx, amqpHeadersCarrier(headers))
+}
+
+// InjectAMQP menulis trace context dari ctx ke headers; headers tidak boleh nil.
+func InjectAMQP(ctx context.Context, headers amqp.Table) {
+	otel.GetTextMapPropagator().Inject(ctx, amqpHeadersCarrier(headers))
+}
+
+// InjectMap mengembalikan trace context dari ctx sebagai map, untuk pesan yang disimpan dulu
+// (outbox) dan baru di-publish kemudian. nil jika ctx tidak membawa span.
+func InjectMap(ctx context.Context) map[string]string {
+	carrier := propagation.MapCarrier{}
+	otel.GetTextMapPropagator().Inject(ctx, carrier)
+	if len(carrier) == 0 {
+		return nil
+	}
+
+	return carrier
+}